@@ -0,0 +1,43 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// MicroversionAtLeast returns whether the microversion configured on the given
+// service client is greater than or equal to the required one; both are
+// expected in the "<major>.<minor>" format (e.g. "2.79"). A service client
+// without a microversion is treated as using the base version of the API.
+func MicroversionAtLeast(service *gophercloud.ServiceClient, required string) bool {
+	currentMajor, currentMinor, ok := parseMicroversion(service.Microversion)
+	if !ok {
+		return false
+	}
+	requiredMajor, requiredMinor, ok := parseMicroversion(required)
+	if !ok {
+		return false
+	}
+	if currentMajor != requiredMajor {
+		return currentMajor > requiredMajor
+	}
+	return currentMinor >= requiredMinor
+}
+
+func parseMicroversion(version string) (int, int, bool) {
+	major, minor, found := strings.Cut(strings.TrimSpace(version), ".")
+	if !found {
+		return 0, 0, false
+	}
+	ma, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, 0, false
+	}
+	mi, err := strconv.Atoi(minor)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ma, mi, true
+}
@@ -9,28 +9,61 @@ import (
 )
 
 type Spec struct {
-	EndpointUrl                *string  `json:"endpoint_url,omitempty" yaml:"endpoint_url,omitempty"`
-	UserID                     *string  `json:"userid,omitempty" yaml:"userid,omitempty"`
-	Username                   *string  `json:"username,omitempty" yaml:"username,omitempty"`
-	Password                   *string  `json:"password,omitempty" yaml:"password,omitempty"`
-	Region                     *string  `json:"region,omitempty" yaml:"region,omitempty"`
-	ProjectID                  *string  `json:"project_id,omitempty" yaml:"project_id,omitempty"`
-	ProjectName                *string  `json:"project_name,omitempty" yaml:"project_name,omitempty"`
-	DomainID                   *string  `json:"domain_id,omitempty" yaml:"domain_id,omitempty"`
-	DomainName                 *string  `json:"domain_name,omitempty" yaml:"domain_name,omitempty"`
-	Installation               *string  `json:"installation,omitempty" yaml:"installation,omitempty"`
-	AccessToken                *string  `json:"access_token,omitempty" yaml:"access_token,omitempty"`
-	AppCredentialID            *string  `json:"app_credential_id,omitempty" yaml:"app_credential_id,omitempty"`
-	AppCredentialSecret        *string  `json:"app_credential_secret,omitempty" yaml:"app_credential_secret,omitempty"`
-	AllowReauth                *bool    `json:"allow_reauth,omitempty" yaml:"allow_reauth,omitempty"`
-	BareMetalV1Microversion    *string  `json:"baremetal_v1_microversion,omitempty" yaml:"baremetal_v1_microversion,omitempty"`
-	IdentityV3Microversion     *string  `json:"keyston_v3_microversion,omitempty" yaml:"keyston_v3_microversion,omitempty"`
-	ComputeV2Microversion      *string  `json:"compute_v2_microversion,omitempty" yaml:"compute_v2_microversion,omitempty"`
-	NetworkingV2Microversion   *string  `json:"networking_v2_microversion,omitempty" yaml:"networking_v2_microversion,omitempty"`
-	BlockStorageV3Microversion *string  `json:"blockstorage_v3_microversion,omitempty" yaml:"blockstorage_v3_microversion,omitempty"`
-	ImageV2Microversion        *string  `json:"image_v2_microversion,omitempty" yaml:"image_v2_microversion,omitempty"`
-	IncludedTables             []string `json:"included_tables,omitempty" yaml:"included_tables,omitempty"`
-	ExcludedTables             []string `json:"excluded_tables,omitempty" yaml:"excluded_tables,omitempty"`
+	EndpointUrl                *string                  `json:"endpoint_url,omitempty" yaml:"endpoint_url,omitempty"`
+	UserID                     *string                  `json:"userid,omitempty" yaml:"userid,omitempty"`
+	Username                   *string                  `json:"username,omitempty" yaml:"username,omitempty"`
+	Password                   *string                  `json:"password,omitempty" yaml:"password,omitempty"`
+	Region                     *string                  `json:"region,omitempty" yaml:"region,omitempty"`
+	ProjectID                  *string                  `json:"project_id,omitempty" yaml:"project_id,omitempty"`
+	ProjectName                *string                  `json:"project_name,omitempty" yaml:"project_name,omitempty"`
+	DomainID                   *string                  `json:"domain_id,omitempty" yaml:"domain_id,omitempty"`
+	DomainName                 *string                  `json:"domain_name,omitempty" yaml:"domain_name,omitempty"`
+	Installation               *string                  `json:"installation,omitempty" yaml:"installation,omitempty"`
+	AccessToken                *string                  `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+	AppCredentialID            *string                  `json:"app_credential_id,omitempty" yaml:"app_credential_id,omitempty"`
+	AppCredentialSecret        *string                  `json:"app_credential_secret,omitempty" yaml:"app_credential_secret,omitempty"`
+	AllowReauth                *bool                    `json:"allow_reauth,omitempty" yaml:"allow_reauth,omitempty"`
+	BareMetalV1Microversion    *string                  `json:"baremetal_v1_microversion,omitempty" yaml:"baremetal_v1_microversion,omitempty"`
+	IdentityV3Microversion     *string                  `json:"keyston_v3_microversion,omitempty" yaml:"keyston_v3_microversion,omitempty"`
+	ComputeV2Microversion      *string                  `json:"compute_v2_microversion,omitempty" yaml:"compute_v2_microversion,omitempty"`
+	NetworkingV2Microversion   *string                  `json:"networking_v2_microversion,omitempty" yaml:"networking_v2_microversion,omitempty"`
+	BlockStorageV3Microversion *string                  `json:"blockstorage_v3_microversion,omitempty" yaml:"blockstorage_v3_microversion,omitempty"`
+	ImageV2Microversion        *string                  `json:"image_v2_microversion,omitempty" yaml:"image_v2_microversion,omitempty"`
+	InstanceActionsWindow      *string                  `json:"instance_actions_window,omitempty" yaml:"instance_actions_window,omitempty"`
+	InstanceActionEvents       *bool                    `json:"instance_action_events,omitempty" yaml:"instance_action_events,omitempty"`
+	InstanceDiagnostics        *InstanceDiagnosticsSpec `json:"instance_diagnostics,omitempty" yaml:"instance_diagnostics,omitempty"`
+	ManageableResources        *bool                    `json:"manageable_resources,omitempty" yaml:"manageable_resources,omitempty"`
+	IncludedTables             []string                 `json:"included_tables,omitempty" yaml:"included_tables,omitempty"`
+	ExcludedTables             []string                 `json:"excluded_tables,omitempty" yaml:"excluded_tables,omitempty"`
+}
+
+// InstanceDiagnosticsSpec restricts the collection of instance diagnostics,
+// which is disabled unless at least one project or tag is listed, to the
+// instances in the given projects or having any of the given tags.
+type InstanceDiagnosticsSpec struct {
+	Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Allows returns whether diagnostics can be collected for an instance in the
+// given project and with the given tags.
+func (s *InstanceDiagnosticsSpec) Allows(projectID string, tags []string) bool {
+	if s == nil {
+		return false
+	}
+	for _, project := range s.Projects {
+		if project == projectID {
+			return true
+		}
+	}
+	for _, allowed := range s.Tags {
+		for _, tag := range tags {
+			if tag == allowed {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (s *Spec) AssignValues() (gophercloud.AuthOptions, error) {
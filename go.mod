@@ -8,6 +8,7 @@ require (
 	github.com/dihedron/cq-plugin-utils v0.0.0-20240311143204-56951d66ea65
 	github.com/gophercloud/gophercloud v1.13.0
 	github.com/rs/zerolog v1.33.0
+	github.com/thoas/go-funk v0.9.3
 )
 
 require (
@@ -49,7 +50,6 @@ require (
 	github.com/spf13/cobra v1.8.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/stretchr/testify v1.9.0 // indirect
-	github.com/thoas/go-funk v0.9.3 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
 	go.opentelemetry.io/otel v1.28.0 // indirect
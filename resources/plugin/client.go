@@ -96,18 +96,36 @@ func getTables(spec *client.Spec) schema.Tables {
 		baremetal.Ports(*os_installation),
 		blockstorage.Attachments(*os_installation),
 		blockstorage.AvailabilityZones(*os_installation),
+		blockstorage.Backups(*os_installation),
+		blockstorage.DefaultTypes(*os_installation),
+		blockstorage.Groups(*os_installation),
+		blockstorage.GroupSnapshots(*os_installation),
+		blockstorage.GroupTypes(*os_installation),
 		blockstorage.Limits(*os_installation),
+		blockstorage.Lineage(*os_installation),
+		blockstorage.ManageableSnapshots(*os_installation),
+		blockstorage.ManageableVolumes(*os_installation),
+		blockstorage.Messages(*os_installation),
+		blockstorage.Pools(*os_installation),
 		blockstorage.QoS(*os_installation),
 		blockstorage.QuotaSets(*os_installation),
 		blockstorage.QuotaSetsUsage(*os_installation),
 		blockstorage.Services(*os_installation),
 		blockstorage.Snapshots(*os_installation),
+		blockstorage.Transfers(*os_installation),
 		blockstorage.Volumes(*os_installation),
+		blockstorage.VolumeTypes(*os_installation),
 		compute.Aggregates(*os_installation),
+		compute.AvailabilityZones(*os_installation),
 		compute.Flavors(*os_installation),
 		compute.Hypervisors(*os_installation),
 		compute.Instances(*os_installation),
+		compute.KeyPairs(*os_installation),
+		compute.Migrations(*os_installation),
+		compute.QuotaSetsUsage(*os_installation),
+		compute.ServerGroups(*os_installation),
 		compute.ServerUsage(*os_installation),
+		compute.Services(*os_installation),
 		identity.Domains(*os_installation),
 		identity.Projects(*os_installation),
 		identity.Regions(*os_installation),
@@ -116,10 +134,25 @@ func getTables(spec *client.Spec) schema.Tables {
 		identity.Users(*os_installation),
 		identity.Services(*os_installation),
 		image.Images(*os_installation),
+		networking.AddressScopes(*os_installation),
+		networking.FirewallGroups(*os_installation),
+		networking.FirewallPolicies(*os_installation),
+		networking.FirewallRules(*os_installation),
 		networking.Networks(*os_installation),
 		networking.Ports(*os_installation),
+		networking.Quotas(*os_installation),
+		networking.RBACPolicies(*os_installation),
+		networking.SecurityGroupExposures(*os_installation),
 		networking.SecurityGroups(*os_installation),
 		networking.SecurityGroupRules(*os_installation),
+		networking.Segments(*os_installation),
+		networking.SubnetPools(*os_installation),
+		networking.Trunks(*os_installation),
+		networking.VPNEndpointGroups(*os_installation),
+		networking.VPNIKEPolicies(*os_installation),
+		networking.VPNIPSecPolicies(*os_installation),
+		networking.VPNIPSecSiteConnections(*os_installation),
+		networking.VPNServices(*os_installation),
 	}
 
 	// must compile these patterns to be included
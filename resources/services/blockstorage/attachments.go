@@ -11,7 +11,9 @@ import (
 	"github.com/dihedron/cq-plugin-utils/format"
 	"github.com/dihedron/cq-plugin-utils/transform"
 	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/attachments"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 )
 
@@ -21,7 +23,7 @@ func Attachments(installation string) *schema.Table {
 		Resolver: fetchAttachments,
 		Transform: transformers.TransformWithStruct(
 			&Attachment{},
-			// transformers.WithPrimaryKeys("ID"),
+			transformers.WithPrimaryKeys("ID"),
 			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
 			transformers.WithSkipFields("Links"),
@@ -180,9 +182,9 @@ func Attachments(installation string) *schema.Table {
 			{
 				Name:        "volume_id",
 				Type:        arrow.BinaryTypes.String,
-				Description: "The ports of the attachment.",
+				Description: "The ID of the attached volume.",
 				Resolver: transform.Apply(
-					transform.OnObjectField("ConnectionInfo.VolumeID"),
+					transform.OnObjectField("VolumeID"),
 					transform.NilIfZero(),
 				),
 			},
@@ -194,73 +196,143 @@ func fetchAttachments(ctx context.Context, meta schema.ClientMeta, parent *schem
 
 	api := meta.(*client.Client)
 
-	// get list of all projects
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.27") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("volume attachments require microversion 3.27 or later")
+		return nil
+	}
+
+	// try listing the attachments of all tenants in one go first, then fall
+	// back to one listing per project only if the cloud rejects the query
+	allAttachments, err := listAttachments(blockstorage, attachments.ListOpts{AllTenants: true})
+	if err != nil {
+		switch err.(type) {
+		case gophercloud.ErrDefault400, gophercloud.ErrDefault403:
+			api.Logger().Warn().Err(err).Msg("listing attachments across all tenants rejected, falling back to per-project listing")
+			allAttachments, err = listAttachmentsByProject(ctx, api, blockstorage)
+			if err != nil {
+				return err
+			}
+		default:
+			api.Logger().Error().Err(err).Msg("error listing attachments across all tenants")
+			return err
+		}
+	}
+	api.Logger().Debug().Int("count", len(allAttachments)).Msg("attachments retrieved")
+
+	// the attachments API does not report the owning project, so take it
+	// from the attached volume unless it is already known
+	var volumeProjects map[string]string
+	for _, attachment := range allAttachments {
+		if attachment.ProjectID == nil {
+			if volumeProjects, err = getVolumeProjects(blockstorage); err != nil {
+				api.Logger().Error().Err(err).Msg("error listing volumes to map attachments to projects")
+				return err
+			}
+			break
+		}
+	}
+
+	for _, attachment := range allAttachments {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		attachment := attachment
+		if attachment.ProjectID == nil {
+			if projectID, ok := volumeProjects[attachment.VolumeID]; ok {
+				attachment.ProjectID = &projectID
+			}
+		}
+		//api.Logger().Debug().Str("data", format.ToPrettyJSON(attachment)).Msg("streaming attachment")
+		api.Logger().Debug().Str("id", attachment.ID).Msg("streaming attachment")
+		res <- attachment
+	}
+	return nil
+}
+
+func listAttachments(blockstorage *gophercloud.ServiceClient, opts attachments.ListOpts) ([]*Attachment, error) {
+	allPages, err := attachments.List(blockstorage, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allAttachments := []*Attachment{}
+	if err = attachments.ExtractAttachmentsInto(allPages, &allAttachments); err != nil {
+		return nil, err
+	}
+	return allAttachments, nil
+}
+
+func listAttachmentsByProject(ctx context.Context, api *client.Client, blockstorage *gophercloud.ServiceClient) ([]*Attachment, error) {
+
 	identity, err := api.GetServiceClient(client.IdentityV3)
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error retrieving identity client")
-		return err
+		return nil, err
 	}
 
 	allPages, err := projects.List(identity, &projects.ListOpts{}).AllPages()
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error listing projects")
-		return err
+		return nil, err
 	}
 	allProjects, err := projects.ExtractProjects(allPages)
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error extracting projects")
-		return err
+		return nil, err
 	}
 	api.Logger().Debug().Int("count", len(allProjects)).Msg("projects retrieved")
 
-	projectIDs := []string{}
+	result := []*Attachment{}
 	for _, project := range allProjects {
 		if ctx.Err() != nil {
 			api.Logger().Debug().Msg("context done, exit")
 			break
 		}
-		projectIDs = append(projectIDs, project.ID)
-	}
-
-	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
-	if err != nil {
-		api.Logger().Error().Err(err).Msg("error retrieving blockstorage client")
-		return err
-	}
-
-	// for each project, get the associated attachments
-	opts := attachments.ListOpts{
-		AllTenants: true,
-	}
-	for _, projectID := range projectIDs {
-		opts.ProjectID = projectID
-
-		allPages, err := attachments.List(blockstorage, opts).AllPages()
+		opts := attachments.ListOpts{
+			AllTenants: true,
+			ProjectID:  project.ID,
+		}
+		projectAttachments, err := listAttachments(blockstorage, opts)
 		if err != nil {
 			api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing attachments with options")
-			return err
+			return nil, err
 		}
-		allAttachments := []*Attachment{}
-		err = attachments.ExtractAttachmentsInto(allPages, &allAttachments)
-		if err != nil {
-			api.Logger().Error().Err(err).Msg("error extracting attachments")
-			return err
+		api.Logger().Debug().Str("project", project.ID).Int("count", len(projectAttachments)).Msg("attachments retrieved")
+		for _, attachment := range projectAttachments {
+			if attachment.ProjectID == nil {
+				projectID := project.ID
+				attachment.ProjectID = &projectID
+			}
 		}
-		api.Logger().Debug().Str("project", projectID).Int("count", len(allAttachments)).Msg("attachments retrieved")
+		result = append(result, projectAttachments...)
+	}
+	return result, nil
+}
 
-		for _, attachment := range allAttachments {
-			if ctx.Err() != nil {
-				api.Logger().Debug().Msg("context done, exit")
-				break
-			}
-			attachment := attachment
-			attachment.ProjectID = projectID
-			//api.Logger().Debug().Str("data", format.ToPrettyJSON(attachment)).Msg("streaming attachment")
-			api.Logger().Debug().Str("id", attachment.ID).Msg("streaming attachment")
-			res <- attachment
+// getVolumeProjects returns a map from the ID of each volume in the cloud to
+// the ID of the project that owns it, when known.
+func getVolumeProjects(blockstorage *gophercloud.ServiceClient) (map[string]string, error) {
+	allPages, err := volumes.List(blockstorage, volumes.ListOpts{AllTenants: true}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allVolumes := []*Volume{}
+	if err := volumes.ExtractVolumesInto(allPages, &allVolumes); err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(allVolumes))
+	for _, volume := range allVolumes {
+		if volume.ProjectID != "" {
+			result[volume.ID] = volume.ProjectID
 		}
 	}
-	return nil
+	return result, nil
 }
 
 type Attachment struct {
@@ -272,7 +344,7 @@ type Attachment struct {
 	Instance       string     `json:"instance" cq-name:"instance_id"`
 	Status         string     `json:"status"`
 	AttachMode     string     `json:"attach_mode"`
-	ProjectID      string     `json:"-" cq-name:"project_id"`
+	ProjectID      *string    `json:"project_id"`
 	ConnectionInfo struct {
 		AccessMode       string   `json:"access_mode"`
 		AttachmentID     string   `json:"attachment_id"`
@@ -5,51 +5,60 @@ import (
 
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
 	"github.com/dihedron/cq-plugin-utils/transform"
 	"github.com/dihedron/cq-plugin-utils/utils"
 	"github.com/dihedron/cq-source-openstack/client"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
 )
 
-func VolumesBackups(installation string) *schema.Table {
+func Backups(installation string) *schema.Table {
 	return &schema.Table{
-		Name:     "openstack_blockstorage_volumes_backups_" + installation,
-		Resolver: fetchVolumesBackups,
+		Name:     "openstack_blockstorage_backups_" + installation,
+		Resolver: fetchBackups,
 		Transform: transformers.TransformWithStruct(
 			&Backup{},
 			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
 		),
 	}
 }
 
-func fetchVolumesBackups(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+func fetchBackups(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
 	api := meta.(*client.Client)
 
-	volume := parent.Item.(*Volume)
-
 	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error retrieving client")
 		return err
 	}
 
-	listOpts := backups.ListOpts{
-		VolumeID: volume.ID,
+	opts := backups.ListDetailOpts{
+		AllTenants: true,
 	}
 
-	allPages, err := backups.List(blockstorage, listOpts).AllPages()
+	allPages, err := backups.ListDetail(blockstorage, opts).AllPages()
 	if err != nil {
-		api.Logger().Err(err).Msg("error listing backups")
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing backups with options")
 		return err
 	}
-	allBackups, err := backups.ExtractBackups(allPages)
-	if err != nil {
+	allBackups := []*Backup{}
+	if err = backups.ExtractBackupsInto(allPages, &allBackups); err != nil {
 		api.Logger().Error().Err(err).Msg("error extracting backups")
 		return err
 	}
-	api.Logger().Debug().Str("volume id", volume.ID).Msg("streaming volume backups")
-	res <- allBackups
+	api.Logger().Debug().Int("count", len(allBackups)).Msg("backups retrieved")
+
+	for _, backup := range allBackups {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		backup := backup
+		api.Logger().Debug().Str("id", backup.ID).Str("volume id", backup.VolumeID).Msg("streaming backup")
+		res <- backup
+	}
 
 	return nil
 }
@@ -88,11 +97,20 @@ type Backup struct {
 	DataTimestamp *utils.Time `json:"data_timestamp" cq-type:"timestamp"`
 	// ProjectID is the ID of the project that owns the backup. This is
 	// an admin-only field.
-	ProjectID string `json:"os-backup-project-attr:project_id"`
+	ProjectID string `json:"os-backup-project-attr:project_id" cq-name:"project_id"`
 	// Metadata is metadata about the backup.
 	// This requires microversion 3.43 or later.
 	Metadata *map[string]string `json:"metadata"`
 	// AvailabilityZone is the Availability Zone of the backup.
 	// This requires microversion 3.51 or later.
 	AvailabilityZone *string `json:"availability_zone"`
+	// ParentID is the ID of the backup this incremental backup is based on.
+	ParentID *string `json:"parent_id"`
+	// UserID is the ID of the user that owns the backup.
+	// This requires microversion 3.56 or later.
+	UserID *string `json:"user_id"`
+	// EncryptionKeyID is the ID of the encryption key of the backup, if the
+	// backed up volume is encrypted.
+	// This requires microversion 3.64 or later.
+	EncryptionKeyID *string `json:"encryption_key_id"`
 }
@@ -0,0 +1,154 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+)
+
+const (
+	// DefaultTypeSourceProject marks a default volume type set explicitly on
+	// the project.
+	DefaultTypeSourceProject = "project"
+	// DefaultTypeSourceGlobal marks a project falling back to the default
+	// volume type of the whole cloud.
+	DefaultTypeSourceGlobal = "global"
+)
+
+func DefaultTypes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_default_types_" + installation,
+		Resolver: fetchDefaultTypes,
+		Transform: transformers.TransformWithStruct(
+			&DefaultType{},
+			transformers.WithPrimaryKeys("ProjectID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchDefaultTypes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// per-project default types are only available since microversion 3.62,
+	// which is above the plugin's default: use it for these calls only
+	projectDefaults := *blockstorage
+	if !client.MicroversionAtLeast(&projectDefaults, "3.62") {
+		projectDefaults.Microversion = "3.62"
+	}
+	// before microversion 3.62 the default type is the one of the whole cloud,
+	// after it is the one of the current project
+	globalDefault := *blockstorage
+	if client.MicroversionAtLeast(&globalDefault, "3.62") {
+		globalDefault.Microversion = "3.61"
+	}
+
+	allDefaultTypes, err := ListDefaultTypes(&projectDefaults)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing default volume types")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allDefaultTypes)).Msg("default volume types retrieved")
+	explicit := map[string]*DefaultType{}
+	for _, defaultType := range allDefaultTypes {
+		defaultType.Source = DefaultTypeSourceProject
+		explicit[defaultType.ProjectID] = defaultType
+	}
+
+	var globalTypeID *string
+	globalType, err := volumetypes.Get(&globalDefault, "default").Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); !ok {
+			api.Logger().Error().Err(err).Msg("error retrieving global default volume type")
+			return err
+		}
+		api.Logger().Warn().Msg("no global default volume type configured")
+	} else {
+		globalTypeID = &globalType.ID
+	}
+
+	identity, err := api.GetServiceClient(client.IdentityV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving identity client")
+		return err
+	}
+
+	allPages, err := projects.List(identity, &projects.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing projects")
+		return err
+	}
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting projects")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allProjects)).Msg("projects retrieved")
+
+	for _, project := range allProjects {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		defaultType, ok := explicit[project.ID]
+		if ok {
+			delete(explicit, project.ID)
+		} else {
+			defaultType = &DefaultType{
+				ProjectID:    project.ID,
+				VolumeTypeID: globalTypeID,
+				Source:       DefaultTypeSourceGlobal,
+			}
+		}
+		api.Logger().Debug().Str("project id", defaultType.ProjectID).Str("source", defaultType.Source).Msg("streaming default volume type")
+		res <- defaultType
+	}
+
+	// defaults may still be set on projects that no longer exist in Keystone
+	for _, defaultType := range explicit {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		api.Logger().Debug().Str("project id", defaultType.ProjectID).Str("source", defaultType.Source).Msg("streaming default volume type of unknown project")
+		res <- defaultType
+	}
+	return nil
+}
+
+// DefaultType is the volume type used by default when creating volumes in a
+// project, as returned by the Cinder default types API, which is not
+// supported by gophercloud; Source tells whether it is set on the project or
+// inherited from the whole cloud.
+type DefaultType struct {
+	ProjectID    string  `json:"project_id"`
+	VolumeTypeID *string `json:"volume_type_id"`
+	Source       string  `json:"-" cq-name:"source"`
+}
+
+// ListDefaultTypes lists the default volume types of all projects that have
+// one set explicitly.
+func ListDefaultTypes(c *gophercloud.ServiceClient) ([]*DefaultType, error) {
+	var s struct {
+		DefaultTypes []*DefaultType `json:"default_types"`
+	}
+	resp, err := c.Get(c.ServiceURL("default-types"), &s, nil)
+	if _, _, err = gophercloud.ParseResponse(resp, err); err != nil {
+		return nil, err
+	}
+	return s.DefaultTypes, nil
+}
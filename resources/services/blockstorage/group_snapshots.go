@@ -0,0 +1,118 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func GroupSnapshots(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_group_snapshots_" + installation,
+		Resolver: fetchGroupSnapshots,
+		Transform: transformers.TransformWithStruct(
+			&GroupSnapshot{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchGroupSnapshots(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.14") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("group snapshots require microversion 3.14 or later")
+		return nil
+	}
+
+	allPages, err := ListGroupSnapshots(blockstorage).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing group snapshots")
+		return err
+	}
+	allGroupSnapshots, err := ExtractGroupSnapshots(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting group snapshots")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allGroupSnapshots)).Msg("group snapshots retrieved")
+
+	for _, groupSnapshot := range allGroupSnapshots {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		groupSnapshot := groupSnapshot
+		api.Logger().Debug().Str("id", groupSnapshot.ID).Msg("streaming group snapshot")
+		res <- groupSnapshot
+	}
+	return nil
+}
+
+type GroupSnapshot struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Status      string      `json:"status"`
+	GroupID     string      `json:"group_id"`
+	CreatedAt   *utils.Time `json:"created_at" cq-type:"timestamp"`
+	// GroupTypeID is available since microversion 3.29.
+	GroupTypeID *string `json:"group_type_id"`
+	// ProjectID is available since microversion 3.58.
+	ProjectID *string `json:"project_id"`
+}
+
+type GroupSnapshotPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r GroupSnapshotPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"group_snapshots_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r GroupSnapshotPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	groupSnapshots, err := ExtractGroupSnapshots(r)
+	return len(groupSnapshots) == 0, err
+}
+
+// ListGroupSnapshots lists the group snapshots of all projects, which are not
+// supported by gophercloud.
+func ListGroupSnapshots(c *gophercloud.ServiceClient) pagination.Pager {
+	url := c.ServiceURL("group_snapshots", "detail") + "?all_tenants=true"
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return GroupSnapshotPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractGroupSnapshots(r pagination.Page) ([]*GroupSnapshot, error) {
+	var s struct {
+		GroupSnapshots []*GroupSnapshot `json:"group_snapshots"`
+	}
+	err := (r.(GroupSnapshotPage)).ExtractInto(&s)
+	return s.GroupSnapshots, err
+}
@@ -0,0 +1,41 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func GroupTypeSpecs(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_group_type_specs_" + installation,
+		Resolver: fetchGroupTypeSpecs,
+		Transform: transformers.TransformWithStruct(
+			&utils.Pair[string, string]{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchGroupTypeSpecs(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	groupType := parent.Item.(*GroupType)
+
+	for k, v := range groupType.GroupSpecs {
+		pair := &utils.Pair[string, string]{
+			Key:   k,
+			Value: v,
+		}
+		api.Logger().Debug().Str("group type id", groupType.ID).Msg("streaming group type spec")
+		res <- pair
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func GroupTypes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_group_types_" + installation,
+		Resolver: fetchGroupTypes,
+		Transform: transformers.TransformWithStruct(
+			&GroupType{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("GroupSpecs"),
+		),
+		Relations: []*schema.Table{
+			GroupTypeSpecs(installation),
+		},
+	}
+}
+
+func fetchGroupTypes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.11") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("group types require microversion 3.11 or later")
+		return nil
+	}
+
+	allPages, err := ListGroupTypes(blockstorage).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing group types")
+		return err
+	}
+	allGroupTypes, err := ExtractGroupTypes(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting group types")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allGroupTypes)).Msg("group types retrieved")
+
+	for _, groupType := range allGroupTypes {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		groupType := groupType
+		api.Logger().Debug().Str("id", groupType.ID).Msg("streaming group type")
+		res <- groupType
+	}
+	return nil
+}
+
+type GroupType struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	IsPublic    bool              `json:"is_public"`
+	GroupSpecs  map[string]string `json:"group_specs"`
+}
+
+type GroupTypePage struct {
+	pagination.LinkedPageBase
+}
+
+func (r GroupTypePage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"group_types_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r GroupTypePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	groupTypes, err := ExtractGroupTypes(r)
+	return len(groupTypes) == 0, err
+}
+
+// ListGroupTypes lists both the public and the private group types, which are
+// not supported by gophercloud.
+func ListGroupTypes(c *gophercloud.ServiceClient) pagination.Pager {
+	url := c.ServiceURL("group_types") + "?is_public=None"
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return GroupTypePage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractGroupTypes(r pagination.Page) ([]*GroupType, error) {
+	var s struct {
+		GroupTypes []*GroupType `json:"group_types"`
+	}
+	err := (r.(GroupTypePage)).ExtractInto(&s)
+	return s.GroupTypes, err
+}
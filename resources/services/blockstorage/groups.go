@@ -0,0 +1,132 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func Groups(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_groups_" + installation,
+		Resolver: fetchGroups,
+		Transform: transformers.TransformWithStruct(
+			&Group{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchGroups(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.13") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("generic volume groups require microversion 3.13 or later")
+		return nil
+	}
+
+	allPages, err := ListGroups(blockstorage).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing groups")
+		return err
+	}
+	allGroups, err := ExtractGroups(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting groups")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allGroups)).Msg("groups retrieved")
+
+	for _, group := range allGroups {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		group := group
+		api.Logger().Debug().Str("id", group.ID).Msg("streaming group")
+		res <- group
+	}
+	return nil
+}
+
+// Group is a generic volume group; consistency groups are not collected
+// separately, as Cinder has migrated them to generic groups.
+type Group struct {
+	ID               string      `json:"id"`
+	Name             string      `json:"name"`
+	Description      string      `json:"description"`
+	Status           string      `json:"status"`
+	AvailabilityZone string      `json:"availability_zone"`
+	CreatedAt        *utils.Time `json:"created_at" cq-type:"timestamp"`
+	GroupTypeID      string      `json:"group_type" cq-name:"group_type_id"`
+	VolumeTypeIDs    []string    `json:"volume_types" cq-name:"volume_type_ids"`
+	// VolumeIDs is available since microversion 3.25.
+	VolumeIDs []string `json:"volumes" cq-name:"volume_ids"`
+	// GroupSnapshotID and SourceGroupID are set when the group is created
+	// from a group snapshot or cloned from another group (since 3.14).
+	GroupSnapshotID *string `json:"group_snapshot_id"`
+	SourceGroupID   *string `json:"source_group_id"`
+	// ReplicationStatus is available since microversion 3.38.
+	ReplicationStatus *string `json:"replication_status"`
+	// ProjectID is available since microversion 3.58.
+	ProjectID *string `json:"project_id"`
+}
+
+type GroupPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r GroupPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"groups_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r GroupPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	groups, err := ExtractGroups(r)
+	return len(groups) == 0, err
+}
+
+// ListGroups lists the generic volume groups of all projects, which are not
+// supported by gophercloud; the volumes in each group are only listed since
+// microversion 3.25.
+func ListGroups(c *gophercloud.ServiceClient) pagination.Pager {
+	url := c.ServiceURL("groups", "detail") + "?all_tenants=true"
+	if client.MicroversionAtLeast(c, "3.25") {
+		url += "&list_volume=true"
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return GroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractGroups(r pagination.Page) ([]*Group, error) {
+	var s struct {
+		Groups []*Group `json:"groups"`
+	}
+	err := (r.(GroupPage)).ExtractInto(&s)
+	return s.Groups, err
+}
@@ -0,0 +1,167 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+)
+
+// The types of the objects linked by lineage edges.
+const (
+	LineageVolume   = "volume"
+	LineageSnapshot = "snapshot"
+	LineageBackup   = "backup"
+	LineageImage    = "image"
+	LineageInstance = "instance"
+)
+
+// The relations expressed by lineage edges; each edge goes from the derived
+// object (the source) to the object it derives from or is bound to (the
+// target).
+const (
+	LineageCreatedFromSnapshot = "created_from_snapshot"
+	LineageClonedFrom          = "cloned_from"
+	LineageRestoredFromBackup  = "restored_from_backup"
+	LineageBootedFromImage     = "booted_from_image"
+	LineageSnapshotOf          = "snapshot_of"
+	LineageAttachedTo          = "attached_to"
+)
+
+func Lineage(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_lineage_" + installation,
+		Resolver: fetchLineage,
+		Transform: transformers.TransformWithStruct(
+			&LineageEdge{},
+			transformers.WithPrimaryKeys("SourceType", "SourceID", "Relation", "TargetType", "TargetID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchLineage(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	volumeOpts := volumes.ListOpts{
+		AllTenants: true,
+	}
+	allPages, err := volumes.List(blockstorage, volumeOpts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(volumeOpts)).Msg("error listing volumes with options")
+		return err
+	}
+	allVolumes := []*Volume{}
+	if err := volumes.ExtractVolumesInto(allPages, &allVolumes); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting volumes")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allVolumes)).Msg("volumes retrieved")
+
+	snapshotOpts := snapshots.ListOpts{
+		AllTenants: true,
+	}
+	allPages, err = ListSnapshots(blockstorage, snapshotOpts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(snapshotOpts)).Msg("error listing snapshots with options")
+		return err
+	}
+	allSnapshots := []*Snapshot{}
+	if err = ExtractSnapshotsInto(allPages, &allSnapshots); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting snapshots")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allSnapshots)).Msg("snapshots retrieved")
+
+	edges := []*LineageEdge{}
+	for _, volume := range allVolumes {
+		edges = append(edges, getVolumeLineage(volume)...)
+	}
+	for _, snapshot := range allSnapshots {
+		if snapshot.VolumeID != "" {
+			edges = append(edges, &LineageEdge{
+				SourceType: LineageSnapshot,
+				SourceID:   snapshot.ID,
+				Relation:   LineageSnapshotOf,
+				TargetType: LineageVolume,
+				TargetID:   snapshot.VolumeID,
+				ProjectID:  snapshot.ProjectID,
+			})
+		}
+	}
+	api.Logger().Debug().Int("count", len(edges)).Msg("lineage edges computed")
+
+	for _, edge := range edges {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		edge := edge
+		api.Logger().Debug().Str("source", edge.SourceID).Str("relation", edge.Relation).Str("target", edge.TargetID).Msg("streaming lineage edge")
+		res <- edge
+	}
+	return nil
+}
+
+// getVolumeLineage returns the edges linking the given volume to the objects
+// it was created from and to the instances it is attached to.
+func getVolumeLineage(volume *Volume) []*LineageEdge {
+	var projectID *string
+	if volume.ProjectID != "" {
+		projectID = &volume.ProjectID
+	}
+	edges := []*LineageEdge{}
+	add := func(relation string, targetType string, targetID string) {
+		if targetID == "" {
+			return
+		}
+		edges = append(edges, &LineageEdge{
+			SourceType: LineageVolume,
+			SourceID:   volume.ID,
+			Relation:   relation,
+			TargetType: targetType,
+			TargetID:   targetID,
+			ProjectID:  projectID,
+		})
+	}
+
+	add(LineageCreatedFromSnapshot, LineageSnapshot, volume.SnapshotID)
+	add(LineageClonedFrom, LineageVolume, volume.SourceVolID)
+	if volume.BackupID != nil {
+		add(LineageRestoredFromBackup, LineageBackup, *volume.BackupID)
+	}
+	add(LineageBootedFromImage, LineageImage, volume.VolumeImageMetadata["image_id"])
+	// the same server may appear more than once (e.g. with multiattach)
+	servers := map[string]bool{}
+	for _, attachment := range volume.Attachments {
+		if !servers[attachment.ServerID] {
+			servers[attachment.ServerID] = true
+			add(LineageAttachedTo, LineageInstance, attachment.ServerID)
+		}
+	}
+	return edges
+}
+
+// LineageEdge links a block storage object to an object it derives from or
+// is bound to.
+type LineageEdge struct {
+	SourceType string  `json:"source_type"`
+	SourceID   string  `json:"source_id"`
+	Relation   string  `json:"relation"`
+	TargetType string  `json:"target_type"`
+	TargetID   string  `json:"target_id"`
+	ProjectID  *string `json:"project_id"`
+}
@@ -0,0 +1,139 @@
+package blockstorage
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// ManageableSnapshots is only populated when manageable_resources is enabled in the spec,
+// as it queries the storage backend of every cinder-volume service.
+func ManageableSnapshots(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_manageable_snapshots_" + installation,
+		Resolver: fetchManageableSnapshots,
+		Transform: transformers.TransformWithStruct(
+			&ManageableSnapshot{},
+			transformers.WithPrimaryKeys("Host", "Reference"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchManageableSnapshots(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	if api.Spec.ManageableResources == nil || !*api.Spec.ManageableResources {
+		return nil
+	}
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.8") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("manageable resources require microversion 3.8 or later")
+		return nil
+	}
+
+	hosts, err := manageableHosts(api, blockstorage)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+
+		allPages, err := ListManageableSnapshots(blockstorage, host).AllPages()
+		if err != nil {
+			// the backend may not support listing unmanaged resources
+			api.Logger().Warn().Err(err).Str("host", host).Msg("error listing manageable snapshots")
+			continue
+		}
+		allManageableSnapshots, err := ExtractManageableSnapshots(allPages)
+		if err != nil {
+			api.Logger().Error().Err(err).Msg("error extracting manageable snapshots")
+			return err
+		}
+		api.Logger().Debug().Str("host", host).Int("count", len(allManageableSnapshots)).Msg("manageable snapshots retrieved")
+
+		for _, snapshot := range allManageableSnapshots {
+			snapshot := snapshot
+			snapshot.Host = host
+			api.Logger().Debug().Str("host", host).Str("reference", snapshot.Reference).Msg("streaming manageable snapshot")
+			res <- snapshot
+		}
+	}
+	return nil
+}
+
+// ManageableSnapshot is a snapshot on a storage backend that can be brought
+// under the management of Cinder.
+type ManageableSnapshot struct {
+	Host string `json:"-" cq-name:"host"`
+	// Reference is the backend-specific identification of the snapshot.
+	Reference          string                 `json:"-" cq-name:"reference"`
+	ReferenceRaw       map[string]interface{} `json:"reference" cq-name:"reference_details"`
+	SourceReferenceRaw map[string]interface{} `json:"source_reference" cq-name:"source_reference"`
+	Size               int                    `json:"size"`
+	SafeToManage       bool                   `json:"safe_to_manage"`
+	ReasonNotSafe      *string                `json:"reason_not_safe"`
+	CinderID           *string                `json:"cinder_id" cq-name:"snapshot_id"`
+	ExtraInfo          *string                `json:"extra_info"`
+}
+
+func (r *ManageableSnapshot) UnmarshalJSON(b []byte) error {
+	type tmp ManageableSnapshot
+	var s tmp
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = ManageableSnapshot(s)
+	r.Reference = format.ToJSON(r.ReferenceRaw)
+	return nil
+}
+
+type ManageableSnapshotPage struct {
+	pagination.SinglePageBase
+}
+
+func (r ManageableSnapshotPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	snapshots, err := ExtractManageableSnapshots(r)
+	return len(snapshots) == 0, err
+}
+
+// ListManageableSnapshots lists the resources on the backend of the given host that are
+// not managed by Cinder, which is not supported by gophercloud.
+func ListManageableSnapshots(c *gophercloud.ServiceClient, host string) pagination.Pager {
+	query := "?host=" + url.QueryEscape(host)
+	return pagination.NewPager(c, c.ServiceURL("manageable_snapshots", "detail")+query, func(r pagination.PageResult) pagination.Page {
+		return ManageableSnapshotPage{pagination.SinglePageBase(r)}
+	})
+}
+
+func ExtractManageableSnapshots(r pagination.Page) ([]*ManageableSnapshot, error) {
+	var s struct {
+		ManageableSnapshots []*ManageableSnapshot `json:"manageable-snapshots"`
+	}
+	err := (r.(ManageableSnapshotPage)).ExtractInto(&s)
+	return s.ManageableSnapshots, err
+}
@@ -0,0 +1,164 @@
+package blockstorage
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/services"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// ManageableVolumes is only populated when manageable_resources is enabled in the spec,
+// as it queries the storage backend of every cinder-volume service.
+func ManageableVolumes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_manageable_volumes_" + installation,
+		Resolver: fetchManageableVolumes,
+		Transform: transformers.TransformWithStruct(
+			&ManageableVolume{},
+			transformers.WithPrimaryKeys("Host", "Reference"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchManageableVolumes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	if api.Spec.ManageableResources == nil || !*api.Spec.ManageableResources {
+		return nil
+	}
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.8") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("manageable resources require microversion 3.8 or later")
+		return nil
+	}
+
+	hosts, err := manageableHosts(api, blockstorage)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+
+		allPages, err := ListManageableVolumes(blockstorage, host).AllPages()
+		if err != nil {
+			// the backend may not support listing unmanaged resources
+			api.Logger().Warn().Err(err).Str("host", host).Msg("error listing manageable volumes")
+			continue
+		}
+		allManageableVolumes, err := ExtractManageableVolumes(allPages)
+		if err != nil {
+			api.Logger().Error().Err(err).Msg("error extracting manageable volumes")
+			return err
+		}
+		api.Logger().Debug().Str("host", host).Int("count", len(allManageableVolumes)).Msg("manageable volumes retrieved")
+
+		for _, volume := range allManageableVolumes {
+			volume := volume
+			volume.Host = host
+			api.Logger().Debug().Str("host", host).Str("reference", volume.Reference).Msg("streaming manageable volume")
+			res <- volume
+		}
+	}
+	return nil
+}
+
+// ManageableVolume is a volume on a storage backend that can be brought
+// under the management of Cinder.
+type ManageableVolume struct {
+	Host string `json:"-" cq-name:"host"`
+	// Reference is the backend-specific identification of the volume.
+	Reference     string                 `json:"-" cq-name:"reference"`
+	ReferenceRaw  map[string]interface{} `json:"reference" cq-name:"reference_details"`
+	Size          int                    `json:"size"`
+	SafeToManage  bool                   `json:"safe_to_manage"`
+	ReasonNotSafe *string                `json:"reason_not_safe"`
+	CinderID      *string                `json:"cinder_id" cq-name:"volume_id"`
+	ExtraInfo     *string                `json:"extra_info"`
+}
+
+func (r *ManageableVolume) UnmarshalJSON(b []byte) error {
+	type tmp ManageableVolume
+	var s tmp
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = ManageableVolume(s)
+	r.Reference = format.ToJSON(r.ReferenceRaw)
+	return nil
+}
+
+// manageableHosts returns the hosts of the cinder-volume services, whose
+// backends can be queried for manageable resources.
+func manageableHosts(api *client.Client, blockstorage *gophercloud.ServiceClient) ([]string, error) {
+	opts := services.ListOpts{
+		Binary: "cinder-volume",
+	}
+	allPages, err := services.List(blockstorage, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing services")
+		return nil, err
+	}
+	allServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting services")
+		return nil, err
+	}
+	hosts := []string{}
+	for _, service := range allServices {
+		if service.State == "up" && service.Status == "enabled" {
+			hosts = append(hosts, service.Host)
+		}
+	}
+	return hosts, nil
+}
+
+type ManageableVolumePage struct {
+	pagination.SinglePageBase
+}
+
+func (r ManageableVolumePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	volumes, err := ExtractManageableVolumes(r)
+	return len(volumes) == 0, err
+}
+
+// ListManageableVolumes lists the resources on the backend of the given host that are
+// not managed by Cinder, which is not supported by gophercloud.
+func ListManageableVolumes(c *gophercloud.ServiceClient, host string) pagination.Pager {
+	query := "?host=" + url.QueryEscape(host)
+	return pagination.NewPager(c, c.ServiceURL("manageable_volumes", "detail")+query, func(r pagination.PageResult) pagination.Page {
+		return ManageableVolumePage{pagination.SinglePageBase(r)}
+	})
+}
+
+func ExtractManageableVolumes(r pagination.Page) ([]*ManageableVolume, error) {
+	var s struct {
+		ManageableVolumes []*ManageableVolume `json:"manageable-volumes"`
+	}
+	err := (r.(ManageableVolumePage)).ExtractInto(&s)
+	return s.ManageableVolumes, err
+}
@@ -0,0 +1,119 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func Messages(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_messages_" + installation,
+		Resolver: fetchMessages,
+		Transform: transformers.TransformWithStruct(
+			&Message{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchMessages(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if !client.MicroversionAtLeast(blockstorage, "3.3") {
+		api.Logger().Warn().Str("microversion", blockstorage.Microversion).Msg("messages require microversion 3.3 or later")
+		return nil
+	}
+
+	allPages, err := ListMessages(blockstorage).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing messages")
+		return err
+	}
+	allMessages, err := ExtractMessages(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting messages")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allMessages)).Msg("messages retrieved")
+
+	for _, message := range allMessages {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		message := message
+		api.Logger().Debug().Str("id", message.ID).Msg("streaming message")
+		res <- message
+	}
+	return nil
+}
+
+// Message is a user-facing message explaining the failure of an asynchronous
+// operation, as returned by the Cinder messages API, which is not supported
+// by gophercloud.
+type Message struct {
+	ID              string      `json:"id"`
+	EventID         string      `json:"event_id"`
+	UserMessage     string      `json:"user_message"`
+	MessageLevel    string      `json:"message_level"`
+	ResourceType    *string     `json:"resource_type"`
+	ResourceUUID    *string     `json:"resource_uuid" cq-name:"resource_id"`
+	RequestID       string      `json:"request_id"`
+	CreatedAt       *utils.Time `json:"created_at" cq-type:"timestamp"`
+	GuaranteedUntil *utils.Time `json:"guaranteed_until" cq-type:"timestamp"`
+}
+
+type MessagePage struct {
+	pagination.LinkedPageBase
+}
+
+func (r MessagePage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"messages_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r MessagePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	messages, err := ExtractMessages(r)
+	return len(messages) == 0, err
+}
+
+// ListMessages lists the messages of all projects.
+func ListMessages(c *gophercloud.ServiceClient) pagination.Pager {
+	url := c.ServiceURL("messages") + "?all_tenants=true"
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return MessagePage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractMessages(r pagination.Page) ([]*Message, error) {
+	var s struct {
+		Messages []*Message `json:"messages"`
+	}
+	err := (r.(MessagePage)).ExtractInto(&s)
+	return s.Messages, err
+}
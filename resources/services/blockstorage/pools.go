@@ -0,0 +1,204 @@
+package blockstorage
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/schedulerstats"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func Pools(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_pools_" + installation,
+		Resolver: fetchPools,
+		Transform: transformers.TransformWithStruct(
+			&Pool{},
+			transformers.WithPrimaryKeys("Name"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchPools(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := schedulerstats.ListOpts{
+		Detail: true,
+	}
+
+	allPages, err := schedulerstats.List(blockstorage, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing pools with options")
+		return err
+	}
+	allPools, err := ExtractPools(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting pools")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allPools)).Msg("pools retrieved")
+
+	for _, pool := range allPools {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		pool := pool
+		api.Logger().Debug().Str("name", pool.Name).Msg("streaming pool")
+		res <- pool
+	}
+	return nil
+}
+
+// Pool is a plugin-owned version of the gophercloud StoragePool, which drops
+// the capabilities it does not know about and turns the "infinite" and
+// "unknown" capacities reported by some drivers into zeroes; here vendor
+// capabilities are kept as extras and special capacities are flagged.
+type Pool struct {
+	// Name is in the "<host>@<backend>#<pool>" format.
+	Name string `json:"name"`
+	// Host is in the "<host>@<backend>" format, as in the cinder-volume services.
+	Host                         string   `json:"-" cq-name:"host"`
+	Backend                      string   `json:"-" cq-name:"backend"`
+	Pool                         string   `json:"-" cq-name:"pool"`
+	VolumeBackendName            *string  `json:"-" cq-name:"volume_backend_name"`
+	VendorName                   *string  `json:"-" cq-name:"vendor_name"`
+	DriverVersion                *string  `json:"-" cq-name:"driver_version"`
+	StorageProtocol              *string  `json:"-" cq-name:"storage_protocol"`
+	TotalCapacityGB              *float64 `json:"-" cq-name:"total_capacity_gb"`
+	TotalCapacityInfinite        bool     `json:"-" cq-name:"total_capacity_infinite"`
+	FreeCapacityGB               *float64 `json:"-" cq-name:"free_capacity_gb"`
+	FreeCapacityInfinite         bool     `json:"-" cq-name:"free_capacity_infinite"`
+	AllocatedCapacityGB          *float64 `json:"-" cq-name:"allocated_capacity_gb"`
+	ProvisionedCapacityGB        *float64 `json:"-" cq-name:"provisioned_capacity_gb"`
+	ReservedPercentage           *float64 `json:"-" cq-name:"reserved_percentage"`
+	MaxOverSubscriptionRatio     *float64 `json:"-" cq-name:"max_over_subscription_ratio"`
+	MaxOverSubscriptionRatioAuto bool     `json:"-" cq-name:"max_over_subscription_ratio_auto"`
+	ThinProvisioningSupport      *bool    `json:"-" cq-name:"thin_provisioning_support"`
+	ThickProvisioningSupport     *bool    `json:"-" cq-name:"thick_provisioning_support"`
+	QoSSupport                   *bool    `json:"-" cq-name:"qos_support"`
+	Multiattach                  *bool    `json:"-" cq-name:"multiattach"`
+	TotalVolumes                 *float64 `json:"-" cq-name:"total_volumes"`
+	LocationInfo                 *string  `json:"-" cq-name:"location_info"`
+	FilterFunction               *string  `json:"-" cq-name:"filter_function"`
+	GoodnessFunction             *string  `json:"-" cq-name:"goodness_function"`
+	Timestamp                    *string  `json:"-" cq-name:"timestamp"`
+	// Extras holds the vendor-specific capabilities.
+	Extras map[string]interface{} `json:"-" cq-name:"extras"`
+}
+
+func (r *Pool) UnmarshalJSON(b []byte) error {
+	var s struct {
+		Name         string                 `json:"name"`
+		Capabilities map[string]interface{} `json:"capabilities"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = Pool{
+		Name: s.Name,
+	}
+	r.Host, r.Pool, _ = strings.Cut(s.Name, "#")
+	_, r.Backend, _ = strings.Cut(r.Host, "@")
+
+	capabilities := s.Capabilities
+	pop := func(key string) (interface{}, bool) {
+		v, ok := capabilities[key]
+		delete(capabilities, key)
+		return v, ok && v != nil
+	}
+	popString := func(key string) *string {
+		if v, ok := pop(key); ok {
+			if s, ok := v.(string); ok {
+				return &s
+			}
+			s := format.ToJSON(v)
+			return &s
+		}
+		return nil
+	}
+	popBool := func(key string) *bool {
+		if v, ok := pop(key); ok {
+			switch t := v.(type) {
+			case bool:
+				return &t
+			case string:
+				if b, err := strconv.ParseBool(t); err == nil {
+					return &b
+				}
+			}
+			// e.g. drivers reporting [true, false] as "both"
+			capabilities[key] = v
+		}
+		return nil
+	}
+	popFloat := func(key string) (*float64, string) {
+		if v, ok := pop(key); ok {
+			switch t := v.(type) {
+			case float64:
+				return &t, ""
+			case string:
+				if f, err := strconv.ParseFloat(t, 64); err == nil {
+					return &f, ""
+				}
+				return nil, strings.ToLower(t)
+			}
+		}
+		return nil, ""
+	}
+
+	var special string
+	r.VolumeBackendName = popString("volume_backend_name")
+	r.VendorName = popString("vendor_name")
+	r.DriverVersion = popString("driver_version")
+	r.StorageProtocol = popString("storage_protocol")
+	r.TotalCapacityGB, special = popFloat("total_capacity_gb")
+	r.TotalCapacityInfinite = special == "infinite"
+	r.FreeCapacityGB, special = popFloat("free_capacity_gb")
+	r.FreeCapacityInfinite = special == "infinite"
+	r.AllocatedCapacityGB, _ = popFloat("allocated_capacity_gb")
+	r.ProvisionedCapacityGB, _ = popFloat("provisioned_capacity_gb")
+	r.ReservedPercentage, _ = popFloat("reserved_percentage")
+	r.MaxOverSubscriptionRatio, special = popFloat("max_over_subscription_ratio")
+	r.MaxOverSubscriptionRatioAuto = special == "auto"
+	r.ThinProvisioningSupport = popBool("thin_provisioning_support")
+	r.ThickProvisioningSupport = popBool("thick_provisioning_support")
+	r.QoSSupport = popBool("QoS_support")
+	r.Multiattach = popBool("multiattach")
+	r.TotalVolumes, _ = popFloat("total_volumes")
+	r.LocationInfo = popString("location_info")
+	r.FilterFunction = popString("filter_function")
+	r.GoodnessFunction = popString("goodness_function")
+	r.Timestamp = popString("timestamp")
+	if len(capabilities) > 0 {
+		r.Extras = capabilities
+	}
+
+	return nil
+}
+
+func ExtractPools(r pagination.Page) ([]*Pool, error) {
+	var s struct {
+		Pools []*Pool `json:"pools"`
+	}
+	err := (r.(schedulerstats.StoragePoolPage)).ExtractInto(&s)
+	return s.Pools, err
+}
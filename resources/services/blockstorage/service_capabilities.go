@@ -0,0 +1,90 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/services"
+)
+
+func ServiceCapabilities(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_service_capabilities_" + installation,
+		Resolver: fetchServiceCapabilities,
+		Transform: transformers.TransformWithStruct(
+			&ServiceCapability{},
+			transformers.WithPrimaryKeys("Host", "Binary"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchServiceCapabilities(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	service := parent.Item.(services.Service)
+
+	// only running volume services can report the capabilities of their backend
+	if service.Binary != "cinder-volume" || service.State != "up" {
+		return nil
+	}
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	capability, err := GetServiceCapability(blockstorage, service.Host)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Str("host", service.Host).Msg("no capabilities found for volume service")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("host", service.Host).Msg("error retrieving volume service capabilities")
+		return err
+	}
+
+	capability.Host = service.Host
+	capability.Binary = service.Binary
+	api.Logger().Debug().Str("host", service.Host).Msg("streaming volume service capabilities")
+	res <- capability
+	return nil
+}
+
+// ServiceCapability describes the capabilities of the storage backend behind
+// a volume service, as returned by the Cinder capabilities API, which is not
+// supported by gophercloud.
+type ServiceCapability struct {
+	// Host and Binary identify the parent service.
+	Host               string                 `json:"-" cq-name:"host"`
+	Binary             string                 `json:"-" cq-name:"binary"`
+	Namespace          string                 `json:"namespace"`
+	VendorName         string                 `json:"vendor_name"`
+	VolumeBackendName  string                 `json:"volume_backend_name"`
+	PoolName           *string                `json:"pool_name"`
+	DriverVersion      string                 `json:"driver_version"`
+	StorageProtocol    string                 `json:"storage_protocol"`
+	DisplayName        *string                `json:"display_name"`
+	Description        *string                `json:"description"`
+	Visibility         *string                `json:"visibility"`
+	ReplicationTargets []interface{}          `json:"replication_targets"`
+	Properties         map[string]interface{} `json:"properties"`
+}
+
+// GetServiceCapability retrieves the capabilities of the backend served by
+// the volume service running on the given host.
+func GetServiceCapability(c *gophercloud.ServiceClient, host string) (*ServiceCapability, error) {
+	s := &ServiceCapability{}
+	resp, err := c.Get(c.ServiceURL("capabilities", host), s, nil)
+	if _, _, err = gophercloud.ParseResponse(resp, err); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -16,6 +16,9 @@ func Services(installation string) *schema.Table {
 		Transform: transformers.TransformWithStruct(
 			&services.Service{},
 		),
+		Relations: []*schema.Table{
+			ServiceCapabilities(installation),
+		},
 	}
 }
 
@@ -0,0 +1,41 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func SnapshotMetadata(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_snapshot_metadata_" + installation,
+		Resolver: fetchSnapshotMetadata,
+		Transform: transformers.TransformWithStruct(
+			&utils.Pair[string, string]{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchSnapshotMetadata(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	snapshot := parent.Item.(*Snapshot)
+
+	for k, v := range snapshot.Metadata {
+		pair := &utils.Pair[string, string]{
+			Key:   k,
+			Value: v,
+		}
+		api.Logger().Debug().Str("snapshot id", snapshot.ID).Msg("streaming snapshot metadata")
+		res <- pair
+	}
+
+	return nil
+}
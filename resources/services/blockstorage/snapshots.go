@@ -2,12 +2,18 @@ package blockstorage
 
 import (
 	"context"
+	"time"
 
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/transformers"
 	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
 	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/pagination"
 )
 
 func Snapshots(installation string) *schema.Table {
@@ -15,9 +21,14 @@ func Snapshots(installation string) *schema.Table {
 		Name:     "openstack_blockstorage_snapshots_" + installation,
 		Resolver: fetchSnapshots,
 		Transform: transformers.TransformWithStruct(
-			&snapshots.Snapshot{},
+			&Snapshot{},
 			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
 		),
+		Relations: []*schema.Table{
+			SnapshotMetadata(installation),
+		},
 	}
 }
 
@@ -30,27 +41,101 @@ func fetchSnapshots(ctx context.Context, meta schema.ClientMeta, parent *schema.
 		return err
 	}
 
+	// retrieve the IDs of all existing volumes, to spot orphaned snapshots
+	volumeOpts := volumes.ListOpts{
+		AllTenants: true,
+	}
+	allPages, err := volumes.List(blockstorage, volumeOpts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(volumeOpts)).Msg("error listing volumes with options")
+		return err
+	}
+	allVolumes := []struct {
+		ID string `json:"id"`
+	}{}
+	if err := volumes.ExtractVolumesInto(allPages, &allVolumes); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting volumes")
+		return err
+	}
+	volumeIDs := map[string]bool{}
+	for _, volume := range allVolumes {
+		volumeIDs[volume.ID] = true
+	}
+
 	opts := snapshots.ListOpts{
 		AllTenants: true,
 	}
 
-	allPages, err := snapshots.List(blockstorage, opts).AllPages()
+	allPages, err = ListSnapshots(blockstorage, opts).AllPages()
 	if err != nil {
 		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing snapshots with options")
 		return err
 	}
-	allSnapshots, err := snapshots.ExtractSnapshots(allPages)
-	if err != nil {
+	allSnapshots := []*Snapshot{}
+	if err = ExtractSnapshotsInto(allPages, &allSnapshots); err != nil {
 		api.Logger().Err(err).Msg("error extracting snapshots")
 		return err
 	}
+	api.Logger().Debug().Int("count", len(allSnapshots)).Msg("snapshots retrieved")
+
+	now := time.Now()
 	for _, snapshot := range allSnapshots {
 		if ctx.Err() != nil {
 			api.Logger().Debug().Msg("context done, exit")
 			break
 		}
+		snapshot := snapshot
+		exists := volumeIDs[snapshot.VolumeID]
+		snapshot.SourceVolumeExists = &exists
+		if snapshot.CreatedAt != nil && !time.Time(*snapshot.CreatedAt).IsZero() {
+			age := int(now.Sub(time.Time(*snapshot.CreatedAt)).Hours() / 24)
+			snapshot.AgeDays = &age
+		}
 		api.Logger().Debug().Str("data", snapshot.ID).Msg("streaming snapshot")
 		res <- snapshot
 	}
 	return nil
 }
+
+type Snapshot struct {
+	ID          string            `json:"id"`
+	CreatedAt   *utils.Time       `json:"created_at" cq-type:"timestamp"`
+	UpdatedAt   *utils.Time       `json:"updated_at" cq-type:"timestamp"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	VolumeID    string            `json:"volume_id"`
+	Status      string            `json:"status"`
+	Size        int               `json:"size"`
+	Metadata    map[string]string `json:"metadata"`
+	Progress    *string           `json:"os-extended-snapshot-attributes:progress" cq-name:"progress"`
+	ProjectID   *string           `json:"os-extended-snapshot-attributes:project_id" cq-name:"project_id"`
+	// GroupSnapshotID is available since microversion 3.14.
+	GroupSnapshotID *string `json:"group_snapshot_id"`
+	// UserID is available since microversion 3.41.
+	UserID *string `json:"user_id"`
+	// ConsumesQuota is available since microversion 3.65.
+	ConsumesQuota *bool `json:"consumes_quota"`
+	// AgeDays and SourceVolumeExists are computed at collection time.
+	AgeDays            *int  `json:"-" cq-name:"age_days"`
+	SourceVolumeExists *bool `json:"-" cq-name:"source_volume_exists"`
+}
+
+// ListSnapshots lists the snapshots in detail, as the plain listing used by
+// gophercloud lacks the extended attributes (progress and project ID).
+func ListSnapshots(c *gophercloud.ServiceClient, opts snapshots.ListOptsBuilder) pagination.Pager {
+	url := c.ServiceURL("snapshots", "detail")
+	if opts != nil {
+		query, err := opts.ToSnapshotListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return snapshots.SnapshotPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractSnapshotsInto(r pagination.Page, v interface{}) error {
+	return r.(snapshots.SnapshotPage).Result.ExtractIntoSlicePtr(v, "snapshots")
+}
@@ -0,0 +1,80 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumetransfers"
+)
+
+func Transfers(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_transfers_" + installation,
+		Resolver: fetchTransfers,
+		Transform: transformers.TransformWithStruct(
+			&Transfer{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchTransfers(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := volumetransfers.ListOpts{
+		AllTenants: true,
+	}
+
+	allPages, err := volumetransfers.List(blockstorage, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing transfers with options")
+		return err
+	}
+	allTransfers := []*Transfer{}
+	if err = volumetransfers.ExtractTransfersInto(allPages, &allTransfers); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting transfers")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allTransfers)).Msg("transfers retrieved")
+
+	for _, transfer := range allTransfers {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		transfer := transfer
+		api.Logger().Debug().Str("id", transfer.ID).Str("volume id", transfer.VolumeID).Msg("streaming transfer")
+		res <- transfer
+	}
+	return nil
+}
+
+// Transfer is a pending volume transfer; the authorisation key, which Cinder
+// only returns when the transfer is created, is never retained.
+type Transfer struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	VolumeID  string      `json:"volume_id"`
+	CreatedAt *utils.Time `json:"created_at" cq-type:"timestamp"`
+	// NoSnapshots is available since microversion 3.55.
+	NoSnapshots *bool `json:"no_snapshots"`
+	// SourceProjectID, DestinationProjectID and Accepted are available since
+	// microversion 3.57.
+	SourceProjectID      *string `json:"source_project_id"`
+	DestinationProjectID *string `json:"destination_project_id"`
+	Accepted             *bool   `json:"accepted"`
+}
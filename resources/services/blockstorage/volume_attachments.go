@@ -0,0 +1,41 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func VolumeAttachments(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_volume_attachments_" + installation,
+		Resolver: fetchVolumeAttachments,
+		Transform: transformers.TransformWithStruct(
+			&VolumeAttachment{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVolumeAttachments(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	volume := parent.Item.(*Volume)
+
+	for _, attachment := range volume.Attachments {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		attachment := attachment
+		api.Logger().Debug().Str("volume id", volume.ID).Str("server id", attachment.ServerID).Msg("streaming volume attachment")
+		res <- attachment
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func VolumeMetadata(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_volume_metadata_" + installation,
+		Resolver: fetchVolumeMetadata,
+		Transform: transformers.TransformWithStruct(
+			&utils.Pair[string, string]{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVolumeMetadata(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	volume := parent.Item.(*Volume)
+
+	for k, v := range volume.Metadata {
+		pair := &utils.Pair[string, string]{
+			Key:   k,
+			Value: v,
+		}
+		api.Logger().Debug().Str("volume id", volume.ID).Msg("streaming volume metadata")
+		res <- pair
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
+)
+
+func VolumeTypeAccesses(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_volume_type_accesses_" + installation,
+		Resolver: fetchVolumeTypeAccesses,
+		Transform: transformers.TransformWithStruct(
+			&volumetypes.VolumeTypeAccess{},
+			transformers.WithPrimaryKeys("VolumeTypeID", "ProjectID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVolumeTypeAccesses(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	volumeType := parent.Item.(*VolumeType)
+
+	// public volume types are accessible by all projects
+	if volumeType.IsPublic {
+		return nil
+	}
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	allPages, err := volumetypes.ListAccesses(blockstorage, volumeType.ID).AllPages()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Err(err).Str("volume type id", volumeType.ID).Msg("no volume type accesses for volume type")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("volume type id", volumeType.ID).Msg("error listing volume type accesses")
+		return err
+	}
+	allAccesses, err := volumetypes.ExtractAccesses(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting volume type accesses")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allAccesses)).Msg("volume type accesses retrieved")
+
+	for _, access := range allAccesses {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		api.Logger().Debug().Str("volume type id", access.VolumeTypeID).Str("project id", access.ProjectID).Msg("streaming volume type access")
+		res <- access
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func VolumeTypeExtraSpecs(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_volume_type_extra_specs_" + installation,
+		Resolver: fetchVolumeTypeExtraSpecs,
+		Transform: transformers.TransformWithStruct(
+			&utils.Pair[string, string]{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVolumeTypeExtraSpecs(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	volumeType := parent.Item.(*VolumeType)
+
+	for k, v := range volumeType.ExtraSpecs {
+		pair := &utils.Pair[string, string]{
+			Key:   k,
+			Value: v,
+		}
+		api.Logger().Debug().Str("volume type id", volumeType.ID).Msg("streaming volume type extra spec")
+		res <- pair
+	}
+
+	return nil
+}
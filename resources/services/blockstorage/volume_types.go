@@ -0,0 +1,117 @@
+package blockstorage
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
+)
+
+func VolumeTypes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_blockstorage_volume_types_" + installation,
+		Resolver: fetchVolumeTypes,
+		Transform: transformers.TransformWithStruct(
+			&VolumeType{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("ExtraSpecs"),
+		),
+		Relations: []*schema.Table{
+			VolumeTypeAccesses(installation),
+			VolumeTypeExtraSpecs(installation),
+		},
+	}
+}
+
+func fetchVolumeTypes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	blockstorage, err := api.GetServiceClient(client.BlockStorageV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// by default only public types are listed, even to administrators
+	opts := VolumeTypeListOpts{
+		IsPublic: "None",
+	}
+
+	allPages, err := volumetypes.List(blockstorage, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing volume types with options")
+		return err
+	}
+	allVolumeTypes := []*VolumeType{}
+	if err = volumetypes.ExtractVolumeTypesInto(allPages, &allVolumeTypes); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting volume types")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allVolumeTypes)).Msg("volume types retrieved")
+
+	for _, volumeType := range allVolumeTypes {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		volumeType := volumeType
+
+		encryption, err := volumetypes.GetEncryption(blockstorage, volumeType.ID).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); !ok {
+				api.Logger().Error().Err(err).Str("volume type id", volumeType.ID).Msg("error retrieving volume type encryption")
+				return err
+			}
+			api.Logger().Warn().Str("volume type id", volumeType.ID).Msg("volume type encryption not found")
+		}
+		// types without encryption return an empty object
+		volumeType.Encrypted = encryption != nil && encryption.EncryptionID != ""
+		if volumeType.Encrypted {
+			volumeType.EncryptionProvider = &encryption.Provider
+			volumeType.EncryptionCipher = &encryption.Cipher
+			volumeType.EncryptionKeySize = &encryption.KeySize
+			volumeType.EncryptionControlLocation = &encryption.ControlLocation
+		}
+
+		api.Logger().Debug().Str("id", volumeType.ID).Msg("streaming volume type")
+		res <- volumeType
+	}
+	return nil
+}
+
+// VolumeTypeListOpts implements volumetypes.ListOptsBuilder with the
+// visibility filter, which is not supported by gophercloud; "None" lists both
+// public and private types.
+type VolumeTypeListOpts struct {
+	IsPublic string `q:"is_public"`
+}
+
+func (opts VolumeTypeListOpts) ToVolumeTypeListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+type VolumeType struct {
+	ID                        string            `json:"id"`
+	Name                      string            `json:"name"`
+	Description               string            `json:"description"`
+	IsPublic                  bool              `json:"is_public"`
+	QoSSpecsID                *string           `json:"qos_specs_id" cq-name:"qos_specs_id"`
+	ExtraSpecs                map[string]string `json:"extra_specs"`
+	Encrypted                 bool              `json:"-" cq-name:"encrypted"`
+	EncryptionProvider        *string           `json:"-" cq-name:"encryption_provider"`
+	EncryptionCipher          *string           `json:"-" cq-name:"encryption_cipher"`
+	EncryptionKeySize         *int              `json:"-" cq-name:"encryption_key_size"`
+	EncryptionControlLocation *string           `json:"-" cq-name:"encryption_control_location"`
+}
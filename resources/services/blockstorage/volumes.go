@@ -23,11 +23,11 @@ func Volumes(installation string) *schema.Table {
 			transformers.WithPrimaryKeys("ID"),
 			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
-
-			transformers.WithSkipFields("Links"),
+			transformers.WithSkipFields("Links", "Attachments"),
 		),
 		Relations: []*schema.Table{
-			VolumesBackups(installation),
+			VolumeAttachments(installation),
+			VolumeMetadata(installation),
 		},
 	}
 }
@@ -65,7 +65,7 @@ func fetchVolumes(ctx context.Context, meta schema.ClientMeta, parent *schema.Re
 		}
 		volume := volume
 		// api.Logger().Debug().Str("data", format.ToPrettyJSON(volume)).Msg("streaming volume")
-		api.Logger().Debug().Str("data", volume.ID).Msg("streaming volume")
+		api.Logger().Debug().Str("id", volume.ID).Msg("streaming volume")
 		res <- volume
 	}
 	return nil
@@ -81,11 +81,11 @@ type Volume struct {
 	// AvailabilityZone is which availability zone the volume is in.
 	AvailabilityZone string `json:"availability_zone"`
 	// The date when this volume was created.
-	CreatedAt utils.Time `json:"created_at"`
+	CreatedAt *utils.Time `json:"created_at" cq-type:"timestamp"`
 	// The date when this volume was last updated
-	UpdatedAt utils.Time `json:"updated_at"`
+	UpdatedAt *utils.Time `json:"updated_at" cq-type:"timestamp"`
 	// Instances onto which the volume is attached.
-	Attachments []volumes.Attachment `json:"attachments"`
+	Attachments []VolumeAttachment `json:"attachments"`
 	// Human-readable display name for the volume.
 	Name string `json:"name"`
 	// Human-readable description for the volume.
@@ -120,11 +120,27 @@ type Volume struct {
 	// The volume migration status
 	MigrationStatus string `json:"migration_status"`
 
-	OsVolHostAttrHost         string `json:"os-vol-host-attr:host" cq-name:"host"`
-	OsVolMigStatusAttrMigstat string `json:"os-vol-mig-status-attr:migstat" cq-name:"migration_status"`
-	OsVolMigStatusAttrNameID  string `json:"os-vol-mig-status-attr:name_id" cq-name:"migration_status_name"`
-	OsVolTenantAttrTenantID   string `json:"os-vol-tenant-attr:tenant_id" cq-name:"migration_status_tenant"`
-	ProviderID                string `json:"provider_id"`
-	ServiceUUID               string `json:"service_uuid"`
-	SharedTargets             bool   `json:"shared_targets"`
+	// The ID of the project that owns the volume (admin only).
+	ProjectID string `json:"os-vol-tenant-attr:tenant_id" cq-name:"project_id"`
+	// The backend host the volume is scheduled on (admin only).
+	Host string `json:"os-vol-host-attr:host" cq-name:"host"`
+	// The internal migration status as seen by the administrator (admin only).
+	MigrationStatusInternal string `json:"os-vol-mig-status-attr:migstat" cq-name:"migration_status_internal"`
+	// The ID of the volume whose backend storage is used while a migration
+	// is in progress (admin only).
+	MigrationNameID string `json:"os-vol-mig-status-attr:name_id" cq-name:"migration_name_id"`
+	ProviderID      string `json:"provider_id"`
+	ServiceUUID     string `json:"service_uuid"`
+	SharedTargets   bool   `json:"shared_targets"`
+}
+
+// VolumeAttachment describes the attachment of a volume to a server.
+type VolumeAttachment struct {
+	ID           string      `json:"id"`
+	AttachmentID string      `json:"attachment_id"`
+	VolumeID     string      `json:"volume_id"`
+	ServerID     string      `json:"server_id"`
+	HostName     *string     `json:"host_name"`
+	Device       string      `json:"device"`
+	AttachedAt   *utils.Time `json:"attached_at" cq-type:"timestamp"`
 }
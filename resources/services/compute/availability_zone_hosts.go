@@ -0,0 +1,81 @@
+package compute
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
+)
+
+func AvailabilityZoneHosts(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_availability_zone_hosts_" + installation,
+		Resolver: fetchAvailabilityZoneHosts,
+		Transform: transformers.TransformWithStruct(
+			&AvailabilityZoneHost{},
+			transformers.WithPrimaryKeys("ZoneName", "Host", "Service"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "updated_at",
+				Type:        arrow.FixedWidthTypes.Timestamp_us,
+				Description: "The time at which the service was last updated.",
+				Resolver: transform.Apply(
+					transform.OnObjectField("UpdatedAt"),
+					transform.NilIfZero(),
+				),
+			},
+		},
+	}
+}
+
+// AvailabilityZoneHost is a service running on a host in an availability zone.
+type AvailabilityZoneHost struct {
+	ZoneName  string    `json:"zone_name"`
+	Host      string    `json:"host"`
+	Service   string    `json:"service"`
+	Active    bool      `json:"active"`
+	Available bool      `json:"available"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func fetchAvailabilityZoneHosts(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	zone := parent.Item.(availabilityzones.AvailabilityZone)
+
+	hosts := make([]string, 0, len(zone.Hosts))
+	for host := range zone.Hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		for service, state := range zone.Hosts[host] {
+			if ctx.Err() != nil {
+				api.Logger().Debug().Msg("context done, exit")
+				return nil
+			}
+			api.Logger().Debug().Str("zone", zone.ZoneName).Str("host", host).Str("service", service).Msg("streaming availability zone host")
+			res <- AvailabilityZoneHost{
+				ZoneName:  zone.ZoneName,
+				Host:      host,
+				Service:   service,
+				Active:    state.Active,
+				Available: state.Available,
+				UpdatedAt: state.UpdatedAt,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
+)
+
+func AvailabilityZones(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_availability_zones_" + installation,
+		Resolver: fetchAvailabilityZones,
+		Transform: transformers.TransformWithStruct(
+			&availabilityzones.AvailabilityZone{},
+			transformers.WithPrimaryKeys("ZoneName"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithUnwrapStructFields("ZoneState"),
+			transformers.WithSkipFields("Hosts"),
+		),
+		Relations: []*schema.Table{
+			AvailabilityZoneHosts(installation),
+		},
+	}
+}
+
+func fetchAvailabilityZones(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// the detailed listing also returns the internal zone and the hosts in
+	// each zone, along with the state of their services
+	allPages, err := availabilityzones.ListDetail(compute).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing availability zones")
+		return err
+	}
+	allZones, err := availabilityzones.ExtractAvailabilityZones(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting availability zones")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allZones)).Msg("availability zones retrieved")
+
+	for _, zone := range allZones {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		zone := zone
+		api.Logger().Debug().Str("name", zone.ZoneName).Msg("streaming availability zone")
+		res <- zone
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+)
+
+func HypervisorServers(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_hypervisor_servers_" + installation,
+		Resolver: fetchHypervisorServers,
+		Transform: transformers.TransformWithStruct(
+			&hypervisors.Server{},
+			transformers.WithPrimaryKeys("UUID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchHypervisorServers(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	hypervisor := parent.Item.(*Hypervisor)
+
+	servers := hypervisor.Servers
+	if servers == nil {
+		compute, err := api.GetServiceClient(client.ComputeV2)
+		if err != nil {
+			api.Logger().Error().Err(err).Msg("error retrieving client")
+			return err
+		}
+		if client.MicroversionAtLeast(compute, "2.53") {
+			// the hypervisor has no servers
+			return nil
+		}
+		// before microversion 2.53 the servers must be retrieved by
+		// searching hypervisors by hostname
+		var s struct {
+			Hypervisors []struct {
+				HypervisorHostname string                `json:"hypervisor_hostname"`
+				Servers            *[]hypervisors.Server `json:"servers"`
+			} `json:"hypervisors"`
+		}
+		resp, err := compute.Get(compute.ServiceURL("os-hypervisors", hypervisor.HypervisorHostname, "servers"), &s, nil)
+		if _, _, err = gophercloud.ParseResponse(resp, err); err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				api.Logger().Warn().Str("hypervisor", hypervisor.HypervisorHostname).Msg("no servers found for hypervisor")
+				return nil
+			}
+			api.Logger().Error().Err(err).Str("hypervisor", hypervisor.HypervisorHostname).Msg("error listing hypervisor servers")
+			return err
+		}
+		// the hostname is matched as a pattern, keep only the exact match
+		for _, h := range s.Hypervisors {
+			if h.HypervisorHostname == hypervisor.HypervisorHostname {
+				servers = h.Servers
+				break
+			}
+		}
+		if servers == nil {
+			return nil
+		}
+	}
+
+	for _, server := range *servers {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		api.Logger().Debug().Str("hypervisor id", hypervisor.ID).Str("uuid", server.UUID).Msg("streaming hypervisor server")
+		res <- server
+	}
+	return nil
+}
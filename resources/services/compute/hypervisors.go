@@ -2,25 +2,49 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/transformers"
 	"github.com/dihedron/cq-plugin-utils/format"
 	"github.com/dihedron/cq-plugin-utils/transform"
 	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/pagination"
 )
 
-func Hypervisors(installationn string) *schema.Table {
+// hypervisorsDescription documents which columns are populated depending on
+// the configured compute microversion; it ends up in the table docs.
+const hypervisorsDescription = `The columns that are populated depend on the compute microversion (compute_v2_microversion):
+
+| Columns | Microversion |
+| ------- | ------------ |
+| id | any; an integer before 2.53, a UUID since 2.53 |
+| hypervisor_hostname, hypervisor_type, hypervisor_version, host_ip, state, status, service_id, service_host, service_disabled_reason | any |
+| uptime | any; embedded since 2.88, retrieved with one extra request per hypervisor before (and left empty if the virt driver does not support it) |
+| cpu_vendor, cpu_arch, cpu_model, cpu_sockets, cpu_cores, cpu_threads, cpu_features | before 2.88 only |
+| current_workload, disk_available_least, free_disk_gb, free_ram_mb, local_gb, local_gb_used, memory_mb, memory_mb_used, running_vms, vcpus, vcpus_used | before 2.88 only; since then the data is only available in Placement |
+
+The servers in openstack_compute_hypervisor_servers are embedded in the listing since 2.53 and retrieved with one extra request per hypervisor before.`
+
+func Hypervisors(installation string) *schema.Table {
 	return &schema.Table{
-		Name:     "openstack_compute_hypervisors_" + installationn,
-		Resolver: fetchHypervisors,
+		Name:        "openstack_compute_hypervisors_" + installation,
+		Description: hypervisorsDescription,
+		Resolver:    fetchHypervisors,
 		Transform: transformers.TransformWithStruct(
-			&hypervisors.Hypervisor{},
+			&Hypervisor{},
+			transformers.WithPrimaryKeys("ID"),
 			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
-			transformers.WithSkipFields("Links"),
+			transformers.WithUnwrapStructFields("Service"),
+			transformers.WithSkipFields("Servers"),
 		),
+		Relations: []*schema.Table{
+			HypervisorServers(installation),
+		},
 	}
 }
 
@@ -35,13 +59,19 @@ func fetchHypervisors(ctx context.Context, meta schema.ClientMeta, parent *schem
 	}
 
 	opts := hypervisors.ListOpts{}
+	if client.MicroversionAtLeast(compute, "2.53") {
+		// servers are embedded in the listing, there is no need for a
+		// further call per hypervisor
+		withServers := true
+		opts.WithServers = &withServers
+	}
 
-	allPages, err := hypervisors.List(compute, opts).AllPages()
+	allPages, err := ListHypervisors(compute, opts).AllPages()
 	if err != nil {
 		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing hypervisors with options")
 		return err
 	}
-	allHypervisors, err := hypervisors.ExtractHypervisors(allPages)
+	allHypervisors, err := ExtractHypervisors(allPages)
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error extracting hypervisors")
 		return err
@@ -54,9 +84,199 @@ func fetchHypervisors(ctx context.Context, meta schema.ClientMeta, parent *schem
 			break
 		}
 		hypervisor := hypervisor
-		// api.Logger().Debug().Str("data", format.ToPrettyJSON(hypervisor)).Msg("streaming hypervisor")
+		if hypervisor.Uptime == nil && !client.MicroversionAtLeast(compute, "2.88") {
+			// before 2.88 the uptime is only available through a dedicated
+			// call, which is not implemented by all virt drivers
+			uptime, err := hypervisors.GetUptime(compute, hypervisor.ID).Extract()
+			if err != nil {
+				api.Logger().Warn().Err(err).Str("id", hypervisor.ID).Msg("error retrieving hypervisor uptime")
+			} else {
+				hypervisor.Uptime = &uptime.Uptime
+			}
+		}
 		api.Logger().Debug().Str("id", hypervisor.ID).Msg("streaming hypervisor")
 		res <- hypervisor
 	}
 	return nil
 }
+
+// Hypervisor is a plugin-owned version of the gophercloud struct, which fails
+// to unmarshal the payloads returned since microversion 2.88; since then, the
+// capacity and usage fields and the CPU information have been removed from the
+// API (the corresponding data is available in Placement) and the related
+// columns are left empty.
+type Hypervisor struct {
+	// ID is an integer before microversion 2.53 and a UUID after.
+	ID                 string            `json:"-" cq-name:"id"`
+	HypervisorHostname string            `json:"hypervisor_hostname"`
+	HypervisorType     string            `json:"hypervisor_type"`
+	HypervisorVersion  *int              `json:"hypervisor_version"`
+	HostIP             string            `json:"host_ip"`
+	State              string            `json:"state"`
+	Status             string            `json:"status"`
+	Service            HypervisorService `json:"service"`
+	// Uptime is embedded since microversion 2.88, before it is retrieved with
+	// a call per hypervisor.
+	Uptime *string `json:"uptime"`
+	// Servers are embedded since microversion 2.53, before they are retrieved
+	// with a call per hypervisor.
+	Servers *[]hypervisors.Server `json:"servers"`
+	// CPU information, before microversion 2.88 only.
+	CPUVendor   *string  `json:"-" cq-name:"cpu_vendor"`
+	CPUArch     *string  `json:"-" cq-name:"cpu_arch"`
+	CPUModel    *string  `json:"-" cq-name:"cpu_model"`
+	CPUSockets  *int     `json:"-" cq-name:"cpu_sockets"`
+	CPUCores    *int     `json:"-" cq-name:"cpu_cores"`
+	CPUThreads  *int     `json:"-" cq-name:"cpu_threads"`
+	CPUFeatures []string `json:"-" cq-name:"cpu_features"`
+	// Capacity and usage, before microversion 2.88 only.
+	CurrentWorkload    *int `json:"current_workload"`
+	DiskAvailableLeast *int `json:"disk_available_least"`
+	FreeDiskGB         *int `json:"free_disk_gb"`
+	FreeRamMB          *int `json:"free_ram_mb"`
+	LocalGB            *int `json:"local_gb"`
+	LocalGBUsed        *int `json:"local_gb_used"`
+	MemoryMB           *int `json:"memory_mb"`
+	MemoryMBUsed       *int `json:"memory_mb_used"`
+	RunningVMs         *int `json:"running_vms"`
+	VCPUs              *int `json:"vcpus"`
+	VCPUsUsed          *int `json:"vcpus_used"`
+}
+
+type HypervisorService struct {
+	ID             string `json:"-" cq-name:"id"`
+	Host           string `json:"host"`
+	DisabledReason string `json:"disabled_reason"`
+}
+
+func (r *Hypervisor) UnmarshalJSON(b []byte) error {
+	type tmp Hypervisor
+	var s struct {
+		tmp
+		ID      interface{} `json:"id"`
+		CPUInfo interface{} `json:"cpu_info"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = Hypervisor(s.tmp)
+
+	r.ID = stringifyID(s.ID)
+
+	// the CPU information is a JSON document embedded as a string before
+	// microversion 2.28 and an object after; some virt drivers return free
+	// text, which is ignored
+	var info []byte
+	switch t := s.CPUInfo.(type) {
+	case string:
+		info = []byte(t)
+	case map[string]interface{}:
+		info, _ = json.Marshal(t)
+	}
+	if len(info) > 0 {
+		var cpu struct {
+			Vendor   *string  `json:"vendor"`
+			Arch     *string  `json:"arch"`
+			Model    *string  `json:"model"`
+			Features []string `json:"features"`
+			Topology *struct {
+				Sockets *int `json:"sockets"`
+				Cores   *int `json:"cores"`
+				Threads *int `json:"threads"`
+			} `json:"topology"`
+		}
+		if err := json.Unmarshal(info, &cpu); err == nil {
+			r.CPUVendor = cpu.Vendor
+			r.CPUArch = cpu.Arch
+			r.CPUModel = cpu.Model
+			r.CPUFeatures = cpu.Features
+			if cpu.Topology != nil {
+				r.CPUSockets = cpu.Topology.Sockets
+				r.CPUCores = cpu.Topology.Cores
+				r.CPUThreads = cpu.Topology.Threads
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *HypervisorService) UnmarshalJSON(b []byte) error {
+	type tmp HypervisorService
+	var s struct {
+		tmp
+		ID interface{} `json:"id"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = HypervisorService(s.tmp)
+
+	r.ID = stringifyID(s.ID)
+
+	return nil
+}
+
+// stringifyID returns the ID as a string, as it is returned as an integer before
+// microversion 2.53 and as a UUID after.
+func stringifyID(id interface{}) string {
+	switch t := id.(type) {
+	case float64:
+		return strconv.Itoa(int(t))
+	case string:
+		return t
+	}
+	return ""
+}
+
+type HypervisorPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r HypervisorPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"hypervisors_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r HypervisorPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	hypervisors, err := ExtractHypervisors(r)
+	return len(hypervisors) == 0, err
+}
+
+// ListHypervisors lists the hypervisors in detail, following the pagination
+// links that are returned since microversion 2.33 (gophercloud only reads the
+// first page).
+func ListHypervisors(c *gophercloud.ServiceClient, opts hypervisors.ListOptsBuilder) pagination.Pager {
+	url := c.ServiceURL("os-hypervisors", "detail")
+	if opts != nil {
+		query, err := opts.ToHypervisorListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return HypervisorPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractHypervisors(r pagination.Page) ([]*Hypervisor, error) {
+	var s struct {
+		Hypervisors []*Hypervisor `json:"hypervisors"`
+	}
+	err := (r.(HypervisorPage)).ExtractInto(&s)
+	return s.Hypervisors, err
+}
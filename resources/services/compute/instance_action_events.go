@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
+)
+
+func InstanceActionEvents(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_instance_action_events_" + installation,
+		Resolver: fetchInstanceActionEvents,
+		Transform: transformers.TransformWithStruct(
+			&InstanceActionEvent{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("Traceback"),
+		),
+	}
+}
+
+func fetchInstanceActionEvents(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	// retrieving the events takes one extra request per action, so it is
+	// only done on demand
+	if api.Spec.InstanceActionEvents == nil || !*api.Spec.InstanceActionEvents {
+		return nil
+	}
+
+	action := parent.Item.(*InstanceAction)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	detail := struct {
+		Events []*InstanceActionEvent `json:"events"`
+	}{}
+	if err := instanceactions.Get(compute, action.InstanceID, action.RequestID).ExtractInto(&detail); err != nil {
+		api.Logger().Error().Err(err).Str("instance id", action.InstanceID).Str("request id", action.RequestID).Msg("error retrieving instance action details")
+		return err
+	}
+
+	for _, event := range detail.Events {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		event := event
+		// tracebacks may leak internal paths and configuration: only record
+		// whether there was one
+		event.HasTraceback = event.Traceback != ""
+		event.Traceback = ""
+		api.Logger().Debug().Str("request id", action.RequestID).Str("event", event.Event).Msg("streaming instance action event")
+		res <- event
+	}
+	return nil
+}
+
+type InstanceActionEvent struct {
+	Event      string      `json:"event"`
+	Result     string      `json:"result"`
+	StartTime  *utils.Time `json:"start_time" cq-type:"timestamp"`
+	FinishTime *utils.Time `json:"finish_time" cq-type:"timestamp"`
+	// Host and HostID are available since microversion 2.62.
+	Host         *string `json:"host"`
+	HostID       *string `json:"hostId" cq-name:"host_id"`
+	Traceback    string  `json:"traceback"`
+	HasTraceback bool    `json:"-" cq-name:"has_traceback"`
+}
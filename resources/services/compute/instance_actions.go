@@ -0,0 +1,118 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
+)
+
+func InstanceActions(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_instance_actions_" + installation,
+		Resolver: fetchInstanceActions,
+		Transform: transformers.TransformWithStruct(
+			&InstanceAction{},
+			transformers.WithPrimaryKeys("InstanceID", "RequestID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+		Relations: []*schema.Table{
+			InstanceActionEvents(installation),
+		},
+	}
+}
+
+func fetchInstanceActions(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	instance := parent.Item.(*Instance)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// bound the history to the configured window, if any; the changes-since
+	// filter is only honoured by the API since microversion 2.58, before that
+	// the actions are filtered here
+	var since *time.Time
+	if api.Spec.InstanceActionsWindow != nil {
+		window, err := ParseWindow(*api.Spec.InstanceActionsWindow)
+		if err != nil {
+			api.Logger().Error().Err(err).Str("window", *api.Spec.InstanceActionsWindow).Msg("invalid instance actions window")
+			return err
+		}
+		t := time.Now().Add(-window).UTC()
+		since = &t
+	}
+
+	opts := instanceactions.ListOpts{}
+	if since != nil && client.MicroversionAtLeast(compute, "2.58") {
+		opts.ChangesSince = since
+	}
+
+	allPages, err := instanceactions.List(compute, instance.ID, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("instance id", instance.ID).Str("options", format.ToPrettyJSON(opts)).Msg("error listing instance actions with options")
+		return err
+	}
+	allActions := []*InstanceAction{}
+	if err = instanceactions.ExtractInstanceActionsInto(allPages, &allActions); err != nil {
+		api.Logger().Error().Err(err).Str("instance id", instance.ID).Msg("error extracting instance actions")
+		return err
+	}
+	api.Logger().Debug().Str("instance id", instance.ID).Int("count", len(allActions)).Msg("instance actions retrieved")
+
+	for _, action := range allActions {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		action := action
+		if since != nil && action.StartTime != nil && time.Time(*action.StartTime).Before(*since) {
+			continue
+		}
+		api.Logger().Debug().Str("instance id", instance.ID).Str("request id", action.RequestID).Msg("streaming instance action")
+		res <- action
+	}
+	return nil
+}
+
+// ParseWindow parses a time window such as "7d", "36h" or "90m"; on top of
+// the units accepted by time.ParseDuration, it supports a whole number of
+// days with the "d" suffix.
+func ParseWindow(window string) (time.Duration, error) {
+	window = strings.TrimSpace(window)
+	if days, found := strings.CutSuffix(window, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days in window %q", window)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(window)
+}
+
+type InstanceAction struct {
+	Action     string      `json:"action"`
+	InstanceID string      `json:"instance_uuid" cq-name:"instance_id"`
+	RequestID  string      `json:"request_id"`
+	UserID     string      `json:"user_id"`
+	ProjectID  string      `json:"project_id"`
+	StartTime  *utils.Time `json:"start_time" cq-type:"timestamp"`
+	Message    string      `json:"message"`
+	// UpdatedAt is available since microversion 2.58.
+	UpdatedAt *utils.Time `json:"updated_at" cq-type:"timestamp"`
+}
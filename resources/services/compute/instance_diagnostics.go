@@ -0,0 +1,150 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/diagnostics"
+)
+
+// InstanceDiagnostics is only populated for the instances allowed by the
+// instance_diagnostics section of the spec, see client.InstanceDiagnosticsSpec.
+func InstanceDiagnostics(installation string) *schema.Table {
+	return &schema.Table{
+		Name:        "openstack_compute_instance_diagnostics_" + installation,
+		Description: "This table is only populated for the instances in the projects or with the tags listed in the instance_diagnostics section of the spec. Console type availability is not collected: Nova can only tell whether a console type is available by opening a console, which creates a live console URL and token, so there is no read-only way to report it.",
+		Resolver:    fetchInstanceDiagnostics,
+		Transform: transformers.TransformWithStruct(
+			&InstanceDiagnostic{},
+			transformers.WithPrimaryKeys("InstanceID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("MemoryDetails"),
+		),
+	}
+}
+
+func fetchInstanceDiagnostics(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	instance := parent.Item.(*Instance)
+
+	if !diagnosticsAllowed(api, instance) {
+		return nil
+	}
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	diagnostic := &InstanceDiagnostic{
+		InstanceID: instance.ID,
+	}
+	result := diagnostics.Get(compute, instance.ID)
+	if result.Err != nil {
+		// diagnostics are not available for instances that are not running
+		// or whose virt driver does not implement them
+		switch result.Err.(type) {
+		case gophercloud.ErrDefault404, gophercloud.ErrDefault409, gophercloud.ErrUnexpectedResponseCode:
+			api.Logger().Warn().Err(result.Err).Str("instance id", instance.ID).Msg("instance diagnostics not available")
+			return nil
+		}
+		api.Logger().Error().Err(result.Err).Str("instance id", instance.ID).Msg("error retrieving instance diagnostics")
+		return result.Err
+	}
+	if client.MicroversionAtLeast(compute, "2.48") {
+		err = result.ExtractInto(diagnostic)
+	} else {
+		err = result.ExtractInto(&diagnostic.Counters)
+	}
+	if err != nil {
+		api.Logger().Error().Err(err).Str("instance id", instance.ID).Msg("error extracting instance diagnostics")
+		return err
+	}
+	if diagnostic.MemoryDetails != nil {
+		diagnostic.MemoryMaximum = diagnostic.MemoryDetails.Maximum
+		diagnostic.MemoryUsed = diagnostic.MemoryDetails.Used
+	}
+
+	api.Logger().Debug().Str("instance id", instance.ID).Msg("streaming instance diagnostics")
+	res <- diagnostic
+	return nil
+}
+
+// diagnosticsAllowed checks the instance against the diagnostics allow-list, so
+// that these calls, which hit the hypervisors, never run fleet-wide.
+func diagnosticsAllowed(api *client.Client, instance *Instance) bool {
+	tags := []string{}
+	if instance.Tags != nil {
+		tags = *instance.Tags
+	}
+	if !api.Spec.InstanceDiagnostics.Allows(instance.TenantID, tags) {
+		api.Logger().Debug().Str("instance id", instance.ID).Msg("instance not in diagnostics allow-list, skipping")
+		return false
+	}
+	return true
+}
+
+// InstanceDiagnostic holds the standardised diagnostics returned since
+// microversion 2.48; before that the format depends on the virt driver and
+// the raw counters are recorded as they are.
+type InstanceDiagnostic struct {
+	InstanceID    string                     `json:"-" cq-name:"instance_id"`
+	State         *string                    `json:"state"`
+	Driver        *string                    `json:"driver"`
+	Hypervisor    *string                    `json:"hypervisor"`
+	HypervisorOS  *string                    `json:"hypervisor_os"`
+	Uptime        *int                       `json:"uptime"`
+	ConfigDrive   *bool                      `json:"config_drive"`
+	NumCPUs       *int                       `json:"num_cpus"`
+	NumNICs       *int                       `json:"num_nics"`
+	NumDisks      *int                       `json:"num_disks"`
+	MemoryDetails *InstanceDiagnosticMemory  `json:"memory_details"`
+	MemoryMaximum *int                       `json:"-" cq-name:"memory_maximum_mb"`
+	MemoryUsed    *int                       `json:"-" cq-name:"memory_used_mb"`
+	CPUDetails    []InstanceDiagnosticCPU    `json:"cpu_details"`
+	NICDetails    []InstanceDiagnosticNIC    `json:"nic_details"`
+	DiskDetails   []InstanceDiagnosticDisk   `json:"disk_details"`
+	Counters      map[string]json.RawMessage `json:"-" cq-name:"counters"`
+}
+
+type InstanceDiagnosticMemory struct {
+	Maximum *int `json:"maximum"`
+	Used    *int `json:"used"`
+}
+
+type InstanceDiagnosticCPU struct {
+	ID          *int `json:"id"`
+	Time        *int `json:"time"`
+	Utilisation *int `json:"utilisation"`
+}
+
+type InstanceDiagnosticNIC struct {
+	MACAddress *string `json:"mac_address"`
+	RxOctets   *int    `json:"rx_octets"`
+	RxErrors   *int    `json:"rx_errors"`
+	RxDrop     *int    `json:"rx_drop"`
+	RxPackets  *int    `json:"rx_packets"`
+	RxRate     *int    `json:"rx_rate"`
+	TxOctets   *int    `json:"tx_octets"`
+	TxErrors   *int    `json:"tx_errors"`
+	TxDrop     *int    `json:"tx_drop"`
+	TxPackets  *int    `json:"tx_packets"`
+	TxRate     *int    `json:"tx_rate"`
+}
+
+type InstanceDiagnosticDisk struct {
+	ReadBytes     *int `json:"read_bytes"`
+	ReadRequests  *int `json:"read_requests"`
+	WriteBytes    *int `json:"write_bytes"`
+	WriteRequests *int `json:"write_requests"`
+	ErrorsCount   *int `json:"errors_count"`
+}
@@ -0,0 +1,65 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
+)
+
+func InstanceInterfaces(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_instance_interfaces_" + installation,
+		Resolver: fetchInstanceInterfaces,
+		Transform: transformers.TransformWithStruct(
+			&attachinterfaces.Interface{},
+			transformers.WithPrimaryKeys("PortID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchInstanceInterfaces(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	instance := parent.Item.(*Instance)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	allPages, err := attachinterfaces.List(compute, instance.ID).AllPages()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Str("instance id", instance.ID).Msg("instance not found while listing interfaces")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("instance id", instance.ID).Msg("error listing instance interfaces")
+		return err
+	}
+	allInterfaces, err := attachinterfaces.ExtractInterfaces(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting instance interfaces")
+		return err
+	}
+	api.Logger().Debug().Str("instance id", instance.ID).Int("count", len(allInterfaces)).Msg("instance interfaces retrieved")
+
+	for _, iface := range allInterfaces {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		iface := iface
+		api.Logger().Debug().Str("instance id", instance.ID).Str("port id", iface.PortID).Msg("streaming instance interface")
+		res <- iface
+	}
+	return nil
+}
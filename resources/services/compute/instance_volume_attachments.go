@@ -0,0 +1,86 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func InstanceVolumeAttachments(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_instance_volume_attachments_" + installation,
+		Resolver: fetchInstanceVolumeAttachments,
+		Transform: transformers.TransformWithStruct(
+			&VolumeAttachment{},
+			transformers.WithPrimaryKeys("ServerID", "VolumeID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchInstanceVolumeAttachments(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	instance := parent.Item.(*Instance)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	allPages, err := volumeattach.List(compute, instance.ID).AllPages()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Str("instance id", instance.ID).Msg("instance not found while listing volume attachments")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("instance id", instance.ID).Msg("error listing instance volume attachments")
+		return err
+	}
+	allAttachments := []*VolumeAttachment{}
+	if err = ExtractVolumeAttachmentsInto(allPages, &allAttachments); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting instance volume attachments")
+		return err
+	}
+	api.Logger().Debug().Str("instance id", instance.ID).Int("count", len(allAttachments)).Msg("instance volume attachments retrieved")
+
+	for _, attachment := range allAttachments {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		attachment := attachment
+		api.Logger().Debug().Str("instance id", instance.ID).Str("volume id", attachment.VolumeID).Msg("streaming instance volume attachment")
+		res <- attachment
+	}
+	return nil
+}
+
+// VolumeAttachment extends the gophercloud struct with the attributes that
+// are only returned at higher microversions.
+type VolumeAttachment struct {
+	ID       string `json:"id"`
+	Device   string `json:"device"`
+	VolumeID string `json:"volumeId" cq-name:"volume_id"`
+	ServerID string `json:"serverId" cq-name:"server_id"`
+	// Tag is available since microversion 2.70.
+	Tag *string `json:"tag"`
+	// DeleteOnTermination is available since microversion 2.79.
+	DeleteOnTermination *bool `json:"delete_on_termination"`
+	// AttachmentID and BDMUUID are available since microversion 2.89.
+	AttachmentID *string `json:"attachment_id"`
+	BDMUUID      *string `json:"bdm_uuid" cq-name:"bdm_uuid"`
+}
+
+func ExtractVolumeAttachmentsInto(r pagination.Page, v interface{}) error {
+	return r.(volumeattach.VolumeAttachmentPage).Result.ExtractIntoSlicePtr(v, "volumeAttachments")
+}
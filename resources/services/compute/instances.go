@@ -2,6 +2,7 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/dihedron/cq-plugin-utils/utils"
 
@@ -22,16 +23,20 @@ func Instances(installation string) *schema.Table {
 			&Instance{},
 			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
-			transformers.WithSkipFields("Links"),
+			transformers.WithSkipFields("Links", "Fault"),
 		),
 		Relations: []*schema.Table{
+			InstanceActions(installation),
 			InstanceAddresses(installation),
 			InstanceAttachedVolumes(installation),
+			InstanceDiagnostics(installation),
 			InstanceFlavors(installation),
 			InstanceFlavorExtraSpecs(installation),
+			InstanceInterfaces(installation),
 			InstanceMetadata(installation),
 			InstanceSecurityGroups(installation),
 			InstanceTags(installation),
+			InstanceVolumeAttachments(installation),
 		},
 		Columns: []schema.Column{
 			{
@@ -61,6 +66,33 @@ func Instances(installation string) *schema.Table {
 					}),
 				),
 			},
+			{
+				Name:        "fault_code",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The error response code of the instance fault, if any.",
+				Resolver:    schema.PathResolver("Fault.Code"),
+			},
+			{
+				Name:        "fault_message",
+				Type:        arrow.BinaryTypes.String,
+				Description: "The error message of the instance fault, if any.",
+				Resolver:    schema.PathResolver("Fault.Message"),
+			},
+			{
+				Name:        "fault_created_at",
+				Type:        arrow.FixedWidthTypes.Timestamp_us,
+				Description: "The time at which the instance fault occurred, if any.",
+				Resolver: transform.Apply(
+					transform.OnObjectField("Fault.Created"),
+					transform.NilIfZero(),
+				),
+			},
+			{
+				Name:        "fault_has_details",
+				Type:        arrow.FixedWidthTypes.Boolean,
+				Description: "Whether the instance fault carries details (not retained, as they may contain a traceback).",
+				Resolver:    schema.PathResolver("Fault.HasDetails"),
+			},
 		},
 	}
 }
@@ -156,6 +188,47 @@ type Instance struct {
 	VMState            string                   `json:"OS-EXT-STS:vm_state" cq-name:"vm_state"`
 	ConfigDrive        string                   `json:"config_drive"`
 	Description        string                   `json:"description"`
-	// NO!!! Fault              servers.Fault            `json:"fault"`
-	// NO!!! TaskState          interface{}              `json:"OS-EXT-STS:task_state"`
+	Fault              *InstanceFault           `json:"fault"`
+	TaskState          *string                  `json:"OS-EXT-STS:task_state" cq-name:"task_state"`
+}
+
+// InstanceFault is the fault recorded on instances in ERROR state; the details
+// (usually a traceback) are not retained, only whether they were present.
+type InstanceFault struct {
+	Code       *int        `json:"code"`
+	Message    *string     `json:"message"`
+	Created    *utils.Time `json:"-"`
+	HasDetails *bool       `json:"-"`
+}
+
+func (r *InstanceFault) UnmarshalJSON(b []byte) error {
+	type tmp InstanceFault
+	var s struct {
+		tmp
+		Created interface{} `json:"created"`
+		Details interface{} `json:"details"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = InstanceFault(s.tmp)
+
+	// an unparseable timestamp must not prevent the instance from being
+	// retrieved, so it is silently dropped
+	if created, ok := s.Created.(string); ok {
+		t := &utils.Time{}
+		if err := t.UnmarshalJSON([]byte(created)); err == nil {
+			r.Created = t
+		}
+	}
+
+	hasDetails := s.Details != nil
+	if details, ok := s.Details.(string); ok {
+		hasDetails = details != ""
+	}
+	r.HasDetails = &hasDetails
+
+	return nil
 }
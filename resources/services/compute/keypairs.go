@@ -0,0 +1,312 @@
+package compute
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func KeyPairs(installation string) *schema.Table {
+	return &schema.Table{
+		Name:        "openstack_compute_keypairs_" + installation,
+		Description: "Nova cannot list the keypairs of all users at once, so this table only covers the keypairs of the user the plugin authenticates as and, since microversion 2.10, those of the users that own at least one instance started with a keypair; the keypairs of any other user are not collected.",
+		Resolver:    fetchKeyPairs,
+		Transform: transformers.TransformWithStruct(
+			&KeyPair{},
+			transformers.WithPrimaryKeys("UserID", "Name"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchKeyPairs(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// Nova has no way to list the keypairs of all users at once, and listing
+	// them for each Keystone user is way too expensive; the keypairs of the
+	// current user are listed first, then those of the users that own instances
+	// that were started with a keypair.
+	identity, err := api.GetServiceClient(client.IdentityV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving identity client")
+		return err
+	}
+	// the keypairs API does not return the owner, so the ID of the current
+	// user must be taken from the token
+	user, err := tokens.Get(identity, api.Client.Token()).ExtractUser()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving current user from token")
+		return err
+	}
+	userIDs := []string{user.ID}
+	if client.MicroversionAtLeast(compute, "2.10") {
+		opts := servers.ListOpts{
+			AllTenants: true,
+		}
+		allPages, err := servers.List(compute, opts).AllPages()
+		if err != nil {
+			api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing instances with options")
+			return err
+		}
+		allInstances := []*Instance{}
+		if err = servers.ExtractServersInto(allPages, &allInstances); err != nil {
+			api.Logger().Error().Err(err).Msg("error extracting instances")
+			return err
+		}
+		owners := map[string]bool{}
+		for _, instance := range allInstances {
+			if instance.KeyName != "" && instance.UserID != "" && instance.UserID != user.ID {
+				owners[instance.UserID] = true
+			}
+		}
+		for userID := range owners {
+			userIDs = append(userIDs, userID)
+		}
+		sort.Strings(userIDs[1:])
+		api.Logger().Debug().Int("count", len(owners)).Msg("keypair owners retrieved")
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+
+		opts := keypairs.ListOpts{}
+		if client.MicroversionAtLeast(compute, "2.10") {
+			opts.UserID = userID
+		}
+
+		allPages, err := ListKeyPairs(compute, opts).AllPages()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				api.Logger().Warn().Str("user id", userID).Msg("user not found while listing keypairs")
+				continue
+			}
+			api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing keypairs with options")
+			return err
+		}
+		allKeyPairs, err := ExtractKeyPairs(allPages)
+		if err != nil {
+			api.Logger().Error().Err(err).Msg("error extracting keypairs")
+			return err
+		}
+		api.Logger().Debug().Str("user id", userID).Int("count", len(allKeyPairs)).Msg("keypairs retrieved")
+
+		for _, keypair := range allKeyPairs {
+			if ctx.Err() != nil {
+				api.Logger().Debug().Msg("context done, exit")
+				break
+			}
+			keypair := keypair
+			if keypair.UserID == "" {
+				keypair.UserID = userID
+			}
+			keypair.parsePublicKey()
+			api.Logger().Debug().Str("user id", keypair.UserID).Str("name", keypair.Name).Msg("streaming keypair")
+			res <- keypair
+		}
+	}
+	return nil
+}
+
+// KeyPair is a Nova keypair; the private key, which Nova only returns when the
+// keypair is generated, is never retained.
+type KeyPair struct {
+	UserID      string  `json:"user_id"`
+	Name        string  `json:"name"`
+	Type        *string `json:"type"` // new in version 2.2
+	Fingerprint string  `json:"fingerprint"`
+	PublicKey   string  `json:"public_key"`
+	// KeyAlgorithm, KeyBits and KeyFingerprintSHA256 are parsed from the
+	// public key (either OpenSSH or x509).
+	KeyAlgorithm         *string `json:"-" cq-name:"key_algorithm"`
+	KeyBits              *int    `json:"-" cq-name:"key_bits"`
+	KeyFingerprintSHA256 *string `json:"-" cq-name:"key_fingerprint_sha256"`
+}
+
+// parsePublicKey fills in the key algorithm, size and fingerprint; unsupported
+// or malformed keys leave them empty.
+func (k *KeyPair) parsePublicKey() {
+	var (
+		algorithm string
+		bits      int
+		blob      []byte
+		err       error
+	)
+	if block, _ := pem.Decode([]byte(k.PublicKey)); block != nil {
+		algorithm, bits, err = parseX509PublicKey(block.Bytes)
+		blob = block.Bytes
+	} else {
+		algorithm, bits, blob, err = parseSSHPublicKey(k.PublicKey)
+	}
+	if err != nil {
+		return
+	}
+	fingerprint := "SHA256:" + base64.RawStdEncoding.EncodeToString(sha256Sum(blob))
+	k.KeyAlgorithm = &algorithm
+	k.KeyBits = &bits
+	k.KeyFingerprintSHA256 = &fingerprint
+}
+
+func sha256Sum(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+// parseSSHPublicKey parses a key in the OpenSSH authorized_keys format
+// ("ssh-rsa AAAAB3Nz... comment") and returns its algorithm, size and wire
+// format blob.
+func parseSSHPublicKey(key string) (string, int, []byte, error) {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", 0, nil, errors.New("invalid public key format")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", 0, nil, err
+	}
+	parts, err := splitSSHWireFormat(blob)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	algorithm := string(parts[0])
+	switch {
+	case algorithm == "ssh-rsa" && len(parts) >= 3:
+		// e, n
+		return algorithm, new(big.Int).SetBytes(parts[2]).BitLen(), blob, nil
+	case algorithm == "ssh-dss" && len(parts) >= 2:
+		// p, q, g, y
+		return algorithm, new(big.Int).SetBytes(parts[1]).BitLen(), blob, nil
+	case algorithm == "ssh-ed25519" || algorithm == "sk-ssh-ed25519@openssh.com":
+		return algorithm, 256, blob, nil
+	case strings.Contains(algorithm, "nistp256"):
+		return algorithm, 256, blob, nil
+	case strings.Contains(algorithm, "nistp384"):
+		return algorithm, 384, blob, nil
+	case strings.Contains(algorithm, "nistp521"):
+		return algorithm, 521, blob, nil
+	}
+	return "", 0, nil, errors.New("unsupported public key algorithm")
+}
+
+// splitSSHWireFormat splits a key blob into its length-prefixed parts.
+func splitSSHWireFormat(blob []byte) ([][]byte, error) {
+	parts := [][]byte{}
+	for len(blob) > 0 {
+		if len(blob) < 4 {
+			return nil, errors.New("truncated public key")
+		}
+		length := binary.BigEndian.Uint32(blob)
+		if uint64(len(blob)-4) < uint64(length) {
+			return nil, errors.New("truncated public key")
+		}
+		parts = append(parts, blob[4:4+length])
+		blob = blob[4+length:]
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("empty public key")
+	}
+	return parts, nil
+}
+
+// parseX509PublicKey parses the certificate of an x509 keypair.
+func parseX509PublicKey(der []byte) (string, int, error) {
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", 0, err
+	}
+	switch key := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "rsa", key.N.BitLen(), nil
+	case *ecdsa.PublicKey:
+		return "ecdsa", key.Curve.Params().BitSize, nil
+	case ed25519.PublicKey:
+		return "ed25519", 256, nil
+	}
+	return "", 0, errors.New("unsupported public key algorithm")
+}
+
+type KeyPairPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r KeyPairPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"keypairs_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r KeyPairPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	keypairs, err := ExtractKeyPairs(r)
+	return len(keypairs) == 0, err
+}
+
+// ListKeyPairs lists keypairs following the pagination links that are returned
+// since microversion 2.35 (gophercloud only reads the first page).
+func ListKeyPairs(c *gophercloud.ServiceClient, opts keypairs.ListOptsBuilder) pagination.Pager {
+	url := c.ServiceURL("os-keypairs")
+	if opts != nil {
+		query, err := opts.ToKeyPairListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return KeyPairPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractKeyPairs(r pagination.Page) ([]*KeyPair, error) {
+	var s struct {
+		KeyPairs []struct {
+			KeyPair *KeyPair `json:"keypair"`
+		} `json:"keypairs"`
+	}
+	err := (r.(KeyPairPage)).ExtractInto(&s)
+	results := make([]*KeyPair, 0, len(s.KeyPairs))
+	for _, pair := range s.KeyPairs {
+		if pair.KeyPair != nil {
+			results = append(results, pair.KeyPair)
+		}
+	}
+	return results, err
+}
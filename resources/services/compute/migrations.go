@@ -0,0 +1,179 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func Migrations(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_migrations_" + installation,
+		Resolver: fetchMigrations,
+		Transform: transformers.TransformWithStruct(
+			&Migration{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithUnwrapAllEmbeddedStructs(),
+			transformers.WithSkipFields("Links"),
+		),
+	}
+}
+
+func fetchMigrations(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	allPages, err := ListMigrations(compute).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing migrations")
+		return err
+	}
+	allMigrations, err := ExtractMigrations(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting migrations")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allMigrations)).Msg("migrations retrieved")
+
+	// the memory and disk progress of live migrations is only exposed by the
+	// per-server API while they are running, so retrieve it once per instance
+	progress := map[string]map[int]*MigrationProgress{}
+	for _, migration := range allMigrations {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		migration := migration
+		if migration.MigrationType == "live-migration" && liveMigrationInProgress[migration.Status] {
+			if _, ok := progress[migration.InstanceID]; !ok {
+				progress[migration.InstanceID], err = getMigrationsProgress(compute, migration.InstanceID)
+				if err != nil {
+					api.Logger().Warn().Err(err).Str("instance id", migration.InstanceID).Msg("error retrieving live migration progress")
+				}
+			}
+			if p, ok := progress[migration.InstanceID][migration.ID]; ok {
+				migration.MigrationProgress = *p
+			}
+		}
+		api.Logger().Debug().Int("id", migration.ID).Str("instance id", migration.InstanceID).Msg("streaming migration")
+		res <- migration
+	}
+	return nil
+}
+
+// liveMigrationInProgress are the statuses of live migrations that have
+// not reached a final state yet.
+var liveMigrationInProgress = map[string]bool{
+	"queued":         true,
+	"preparing":      true,
+	"running":        true,
+	"post-migrating": true,
+}
+
+type Migration struct {
+	ID int `json:"id"`
+	// UUID is available since microversion 2.59.
+	UUID              *string     `json:"uuid"`
+	InstanceID        string      `json:"instance_uuid" cq-name:"instance_id"`
+	MigrationType     string      `json:"migration_type"`
+	Status            string      `json:"status"`
+	SourceCompute     string      `json:"source_compute"`
+	SourceNode        string      `json:"source_node"`
+	DestCompute       string      `json:"dest_compute"`
+	DestNode          string      `json:"dest_node"`
+	DestHost          string      `json:"dest_host"`
+	OldInstanceTypeID int         `json:"old_instance_type_id"`
+	NewInstanceTypeID int         `json:"new_instance_type_id"`
+	CreatedAt         *utils.Time `json:"created_at" cq-type:"timestamp"`
+	UpdatedAt         *utils.Time `json:"updated_at" cq-type:"timestamp"`
+	// UserID and ProjectID are available since microversion 2.80.
+	UserID    *string            `json:"user_id"`
+	ProjectID *string            `json:"project_id"`
+	Links     []gophercloud.Link `json:"links"`
+	MigrationProgress
+}
+
+// MigrationProgress holds the progress of a running live migration.
+type MigrationProgress struct {
+	MemoryTotalBytes     *int64 `json:"memory_total_bytes"`
+	MemoryProcessedBytes *int64 `json:"memory_processed_bytes"`
+	MemoryRemainingBytes *int64 `json:"memory_remaining_bytes"`
+	DiskTotalBytes       *int64 `json:"disk_total_bytes"`
+	DiskProcessedBytes   *int64 `json:"disk_processed_bytes"`
+	DiskRemainingBytes   *int64 `json:"disk_remaining_bytes"`
+}
+
+type MigrationPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r MigrationPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"migrations_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r MigrationPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	migrations, err := ExtractMigrations(r)
+	return len(migrations) == 0, err
+}
+
+// ListMigrations lists the migrations of all instances (os-migrations), which
+// is not supported by gophercloud; pagination is available since microversion
+// 2.59.
+func ListMigrations(c *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(c, c.ServiceURL("os-migrations"), func(r pagination.PageResult) pagination.Page {
+		return MigrationPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractMigrations(r pagination.Page) ([]*Migration, error) {
+	var s struct {
+		Migrations []*Migration `json:"migrations"`
+	}
+	err := (r.(MigrationPage)).ExtractInto(&s)
+	return s.Migrations, err
+}
+
+// getMigrationsProgress retrieves the progress of the in-progress live
+// migrations of the given instance, indexed by migration ID.
+func getMigrationsProgress(c *gophercloud.ServiceClient, instanceID string) (map[int]*MigrationProgress, error) {
+	var s struct {
+		Migrations []struct {
+			ID int `json:"id"`
+			MigrationProgress
+		} `json:"migrations"`
+	}
+	resp, err := c.Get(c.ServiceURL("servers", instanceID, "migrations"), &s, nil)
+	if _, _, err = gophercloud.ParseResponse(resp, err); err != nil {
+		return nil, err
+	}
+	progress := map[int]*MigrationProgress{}
+	for _, migration := range s.Migrations {
+		migration := migration
+		progress[migration.ID] = &migration.MigrationProgress
+	}
+	return progress, nil
+}
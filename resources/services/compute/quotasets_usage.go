@@ -0,0 +1,184 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+)
+
+func QuotaSetsUsage(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_quotasets_usage_" + installation,
+		Resolver: fetchQuotaSetsUsage,
+		Transform: transformers.TransformWithStruct(
+			&quotasets.QuotaDetailSet{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithSkipFields("FixedIPs", "FloatingIPs", "InjectedFileContentBytes", "InjectedFilePathBytes", "InjectedFiles", "KeyPairs", "MetadataItems", "RAM", "SecurityGroupRules", "SecurityGroups", "Cores", "Instances", "ServerGroups", "ServerGroupMembers"),
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "instances_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of instances currently in use.",
+				Resolver:    schema.PathResolver("Instances.InUse"),
+			},
+			{
+				Name:        "instances_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of instances currently reserved.",
+				Resolver:    schema.PathResolver("Instances.Reserved"),
+			},
+			{
+				Name:        "instances_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of instances currently allowed.",
+				Resolver:    schema.PathResolver("Instances.Limit"),
+			},
+			{
+				Name:        "cores_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of cores currently in use.",
+				Resolver:    schema.PathResolver("Cores.InUse"),
+			},
+			{
+				Name:        "cores_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of cores currently reserved.",
+				Resolver:    schema.PathResolver("Cores.Reserved"),
+			},
+			{
+				Name:        "cores_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of cores currently allowed.",
+				Resolver:    schema.PathResolver("Cores.Limit"),
+			},
+			{
+				Name:        "ram_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of megabytes of RAM currently in use.",
+				Resolver:    schema.PathResolver("RAM.InUse"),
+			},
+			{
+				Name:        "ram_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of megabytes of RAM currently reserved.",
+				Resolver:    schema.PathResolver("RAM.Reserved"),
+			},
+			{
+				Name:        "ram_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of megabytes of RAM currently allowed.",
+				Resolver:    schema.PathResolver("RAM.Limit"),
+			},
+			{
+				Name:        "key_pairs_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of key pairs currently in use.",
+				Resolver:    schema.PathResolver("KeyPairs.InUse"),
+			},
+			{
+				Name:        "key_pairs_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of key pairs currently reserved.",
+				Resolver:    schema.PathResolver("KeyPairs.Reserved"),
+			},
+			{
+				Name:        "key_pairs_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of key pairs currently allowed.",
+				Resolver:    schema.PathResolver("KeyPairs.Limit"),
+			},
+			{
+				Name:        "server_groups_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of server groups currently in use.",
+				Resolver:    schema.PathResolver("ServerGroups.InUse"),
+			},
+			{
+				Name:        "server_groups_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of server groups currently reserved.",
+				Resolver:    schema.PathResolver("ServerGroups.Reserved"),
+			},
+			{
+				Name:        "server_groups_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of server groups currently allowed.",
+				Resolver:    schema.PathResolver("ServerGroups.Limit"),
+			},
+			{
+				Name:        "server_group_members_in_use",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of members per server group currently in use.",
+				Resolver:    schema.PathResolver("ServerGroupMembers.InUse"),
+			},
+			{
+				Name:        "server_group_members_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of members per server group currently reserved.",
+				Resolver:    schema.PathResolver("ServerGroupMembers.Reserved"),
+			},
+			{
+				Name:        "server_group_members_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of members per server group currently allowed.",
+				Resolver:    schema.PathResolver("ServerGroupMembers.Limit"),
+			},
+		},
+	}
+}
+
+func fetchQuotaSetsUsage(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	// get list of all projects
+	identity, err := api.GetServiceClient(client.IdentityV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving identity client")
+		return err
+	}
+
+	allPages, err := projects.List(identity, &projects.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing projects")
+		return err
+	}
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting projects")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allProjects)).Msg("projects retrieved")
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	// for each project, get the associated QuotaDetailSet
+	for _, project := range allProjects {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		quotadetailset, err := quotasets.GetDetail(compute, project.ID).Extract()
+		if err != nil {
+			api.Logger().Error().Err(err).Msg("error extracting quota sets for project " + project.ID)
+			return err
+		}
+		// the ID is only returned by some releases, make sure it is set
+		quotadetailset.ID = project.ID
+		api.Logger().Debug().Str("project id", project.ID).Msg("streaming quota set usage")
+		res <- quotadetailset
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+)
+
+func ServerGroupMembers(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_server_group_members_" + installation,
+		Resolver: fetchServerGroupMembers,
+		Transform: transformers.TransformWithStruct(
+			&ServerGroupMember{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchServerGroupMembers(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	group := parent.Item.(*ServerGroup)
+
+	for _, member := range group.MemberDetails {
+		api.Logger().Debug().Str("server group id", group.ID).Str("instance id", member.InstanceID).Msg("streaming server group member")
+		res <- member
+	}
+	return nil
+}
+
+type ServerGroupMember struct {
+	InstanceID         string `json:"instance_id"`
+	Name               string `json:"name"`
+	Host               string `json:"host"`
+	HypervisorHostname string `json:"hypervisor_hostname"`
+}
@@ -0,0 +1,172 @@
+package compute
+
+import (
+	"context"
+	"sort"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func ServerGroups(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_server_groups_" + installation,
+		Resolver: fetchServerGroups,
+		Transform: transformers.TransformWithStruct(
+			&ServerGroup{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("Rules", "MemberDetails"),
+		),
+		Relations: []*schema.Table{
+			ServerGroupMembers(installation),
+		},
+		Columns: []schema.Column{
+			{
+				Name:        "max_server_per_host",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of members that can reside on the same host (anti-affinity only).",
+				Resolver: transform.Apply(
+					transform.OnObjectField("Rules.MaxServerPerHost"),
+					transform.NilIfZero(),
+				),
+			},
+		},
+	}
+}
+
+func fetchServerGroups(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := servergroups.ListOpts{
+		AllProjects: true,
+	}
+
+	allPages, err := servergroups.List(compute, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing server groups with options")
+		return err
+	}
+	allServerGroups := []*ServerGroup{}
+	if err = ExtractServerGroupsInto(allPages, &allServerGroups); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting server groups")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allServerGroups)).Msg("server groups retrieved")
+
+	// retrieve the instances to find out where the members are placed
+	serverOpts := servers.ListOpts{
+		AllTenants: true,
+	}
+	allPages, err = servers.List(compute, serverOpts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(serverOpts)).Msg("error listing instances with options")
+		return err
+	}
+	allInstances := []*Instance{}
+	if err = servers.ExtractServersInto(allPages, &allInstances); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting instances")
+		return err
+	}
+	instances := map[string]*Instance{}
+	for _, instance := range allInstances {
+		instances[instance.ID] = instance
+	}
+
+	for _, group := range allServerGroups {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		group := group
+		group.resolveMembers(instances)
+		api.Logger().Debug().Str("id", group.ID).Msg("streaming server group")
+		res <- group
+	}
+	return nil
+}
+
+func ExtractServerGroupsInto(r pagination.Page, v interface{}) error {
+	return r.(servergroups.ServerGroupPage).Result.ExtractIntoSlicePtr(v, "server_groups")
+}
+
+type ServerGroup struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Policies  []string               `json:"policies"`
+	Members   []string               `json:"members"`
+	UserID    string                 `json:"user_id"`
+	ProjectID string                 `json:"project_id"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	// Policy and Rules require microversion 2.64 or later.
+	Policy *string             `json:"policy"`
+	Rules  *servergroups.Rules `json:"rules"`
+	// AntiAffinityViolated is only set for (soft) anti-affinity groups and
+	// is true when more members share a hypervisor than the policy allows.
+	AntiAffinityViolated *bool               `json:"-" cq-name:"anti_affinity_violated"`
+	ViolatingHosts       []string            `json:"-" cq-name:"anti_affinity_violating_hosts"`
+	MemberDetails        []ServerGroupMember `json:"-"`
+}
+
+// EffectivePolicy returns the policy of the server group, regardless of
+// the microversion used to retrieve it.
+func (g *ServerGroup) EffectivePolicy() string {
+	if g.Policy != nil {
+		return *g.Policy
+	}
+	if len(g.Policies) > 0 {
+		return g.Policies[0]
+	}
+	return ""
+}
+
+// resolveMembers fills in the placement of the group members and checks
+// whether it complies with the anti-affinity policy, if any.
+func (g *ServerGroup) resolveMembers(instances map[string]*Instance) {
+	perHost := map[string]int{}
+	for _, id := range g.Members {
+		member := ServerGroupMember{InstanceID: id}
+		if instance, ok := instances[id]; ok {
+			member.Name = instance.Name
+			member.Host = instance.Host
+			member.HypervisorHostname = instance.HypervisorHostname
+			if instance.HypervisorHostname != "" {
+				perHost[instance.HypervisorHostname]++
+			}
+		}
+		g.MemberDetails = append(g.MemberDetails, member)
+	}
+
+	policy := g.EffectivePolicy()
+	if policy != "anti-affinity" && policy != "soft-anti-affinity" {
+		return
+	}
+	limit := 1
+	if g.Rules != nil && g.Rules.MaxServerPerHost > 0 {
+		limit = g.Rules.MaxServerPerHost
+	}
+	violated := false
+	for host, count := range perHost {
+		if count > limit {
+			violated = true
+			g.ViolatingHosts = append(g.ViolatingHosts, host)
+		}
+	}
+	sort.Strings(g.ViolatingHosts)
+	g.AntiAffinityViolated = &violated
+}
@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/services"
+)
+
+func Services(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_compute_services_" + installation,
+		Resolver: fetchServices,
+		Transform: transformers.TransformWithStruct(
+			&services.Service{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "id",
+				Type:        arrow.BinaryTypes.String,
+				Description: "The ID of the service (a UUID since microversion 2.53, an integer before).",
+				Resolver:    schema.PathResolver("ID"),
+				PrimaryKey:  true,
+			},
+			{
+				Name:        "updated_at",
+				Type:        arrow.FixedWidthTypes.Timestamp_us,
+				Description: "The time at which the service was last updated.",
+				Resolver: transform.Apply(
+					transform.OnObjectField("UpdatedAt"),
+					transform.NilIfZero(),
+				),
+			},
+		},
+	}
+}
+
+func fetchServices(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	compute, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := services.ListOpts{}
+
+	allPages, err := services.List(compute, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing services")
+		return err
+	}
+	allServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting services")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allServices)).Msg("services retrieved")
+
+	for _, service := range allServices {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		service := service
+		api.Logger().Debug().Str("id", service.ID).Str("binary", service.Binary).Str("host", service.Host).Msg("streaming service")
+		res <- service
+	}
+
+	return nil
+}
@@ -24,9 +24,6 @@ func Users(installation string) *schema.Table {
 			&User{},
 			transformers.WithSkipFields("Links", "Options"),
 		),
-		Relations: []*schema.Table{
-			UserKeyPairs(installation),
-		},
 		Columns: []schema.Column{
 			{
 				Name:        "ignore_change_password_upon_first_use",
@@ -0,0 +1,61 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/addressscopes"
+)
+
+func AddressScopes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_address_scopes_" + installation,
+		Resolver: fetchAddressScopes,
+		Transform: transformers.TransformWithStruct(
+			&addressscopes.AddressScope{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchAddressScopes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := addressscopes.ListOpts{}
+
+	allPages, err := addressscopes.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing address scopes with options")
+		return err
+	}
+	allAddressScopes, err := addressscopes.ExtractAddressScopes(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting address scopes")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allAddressScopes)).Msg("address scopes retrieved")
+
+	for _, scope := range allAddressScopes {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		scope := scope
+		api.Logger().Debug().Str("id", scope.ID).Msg("streaming address scope")
+		res <- scope
+	}
+	return nil
+}
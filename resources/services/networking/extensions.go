@@ -0,0 +1,34 @@
+package networking
+
+import (
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions"
+)
+
+const (
+	// FWaaSV2Extension is the alias of the Neutron firewall-as-a-service v2 extension.
+	FWaaSV2Extension = "fwaas_v2"
+	// VPNaaSExtension is the alias of the Neutron VPN-as-a-service extension.
+	VPNaaSExtension = "vpnaas"
+	// QuotaDetailsExtension is the alias of the Neutron quota details extension.
+	QuotaDetailsExtension = "quota_details"
+	// VPNEndpointGroupsExtension is the alias of the Neutron VPN endpoint groups extension.
+	VPNEndpointGroupsExtension = "vpn-endpoint-groups"
+)
+
+// hasExtension checks whether the Neutron extension with the given alias is
+// loaded, so that tables backed by optional service plugins can be skipped
+// instead of failing the sync.
+func hasExtension(api *client.Client, networking *gophercloud.ServiceClient, alias string) (bool, error) {
+	_, err := extensions.Get(networking, alias).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Info().Str("alias", alias).Msg("networking extension not loaded, skipping")
+			return false, nil
+		}
+		api.Logger().Error().Err(err).Str("alias", alias).Msg("error checking networking extension")
+		return false, err
+	}
+	return true, nil
+}
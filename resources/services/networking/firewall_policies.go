@@ -0,0 +1,65 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas_v2/policies"
+)
+
+func FirewallPolicies(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_firewall_policies_" + installation,
+		Resolver: fetchFirewallPolicies,
+		Transform: transformers.TransformWithStruct(
+			&policies.Policy{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchFirewallPolicies(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, FWaaSV2Extension); err != nil || !ok {
+		return err
+	}
+
+	opts := policies.ListOpts{}
+
+	allPages, err := policies.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing firewall policies with options")
+		return err
+	}
+	allPolicies, err := policies.ExtractPolicies(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting firewall policies")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allPolicies)).Msg("firewall policies retrieved")
+
+	for _, policy := range allPolicies {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		policy := policy
+		api.Logger().Debug().Str("id", policy.ID).Msg("streaming firewall policy")
+		res <- policy
+	}
+	return nil
+}
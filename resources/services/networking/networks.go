@@ -2,11 +2,14 @@ package networking
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"time"
 
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/transformers"
 	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
 	"github.com/dihedron/cq-source-openstack/client"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
@@ -19,8 +22,8 @@ func Networks(installation string) *schema.Table {
 		Transform: transformers.TransformWithStruct(
 			&Network{},
 			transformers.WithPrimaryKeys("ID"),
-			// transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
-			// transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
 			transformers.WithSkipFields("Links"),
 		),
 		Relations: []*schema.Table{
@@ -112,4 +115,87 @@ type Network struct {
 
 	// RevisionNumber optionally set via extensions/standard-attr-revisions
 	RevisionNumber int `json:"revision_number"`
+
+	// NetworkType is the nature of the physical network mapped to this network
+	// (e.g. flat, vlan, vxlan or geneve); it is set via extensions/provider
+	// and is only available to administrators.
+	NetworkType string `json:"provider:network_type" cq-name:"provider_network_type"`
+
+	// PhysicalNetwork is the physical network on top of which this network
+	// is implemented (e.g. the name of a bridge mapping).
+	PhysicalNetwork string `json:"provider:physical_network" cq-name:"provider_physical_network"`
+
+	// SegmentationID is the VLAN ID or tunnel key of the network; the API
+	// may return it as a number, a string or null.
+	SegmentationID *int `json:"-" cq-name:"provider_segmentation_id"`
+
+	// Segments is set instead of the provider attributes above when the
+	// network is made of multiple segments (extensions/multi-provider).
+	Segments []NetworkSegment `json:"segments"`
+}
+
+// NetworkSegment is a physical binding of a multi-segment network.
+type NetworkSegment struct {
+	NetworkType     string `json:"provider:network_type"`
+	PhysicalNetwork string `json:"provider:physical_network"`
+	SegmentationID  *int   `json:"provider:segmentation_id"`
+}
+
+func (r *NetworkSegment) UnmarshalJSON(b []byte) error {
+	type tmp NetworkSegment
+	var s struct {
+		tmp
+		SegmentationID interface{} `json:"provider:segmentation_id"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = NetworkSegment(s.tmp)
+
+	if r.SegmentationID, err = parseSegmentationID(s.SegmentationID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseSegmentationID decodes a segmentation ID, which some backends return
+// as a string instead of a number.
+func parseSegmentationID(value interface{}) (*int, error) {
+	switch t := value.(type) {
+	case float64:
+		id := int(t)
+		return &id, nil
+	case string:
+		if t != "" {
+			id, err := strconv.Atoi(t)
+			if err != nil {
+				return nil, err
+			}
+			return &id, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *Network) UnmarshalJSON(b []byte) error {
+	type tmp Network
+	var s struct {
+		tmp
+		SegmentationID interface{} `json:"provider:segmentation_id"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = Network(s.tmp)
+
+	if r.SegmentationID, err = parseSegmentationID(s.SegmentationID); err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,268 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+)
+
+func Quotas(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_quotas_" + installation,
+		Resolver: fetchQuotas,
+		Transform: transformers.TransformWithStruct(
+			&Quota{},
+			transformers.WithPrimaryKeys("ProjectID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithSkipFields("Details"),
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "networks_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of networks currently in use.",
+				Resolver:    schema.PathResolver("Details.Network.Used"),
+			},
+			{
+				Name:        "networks_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of networks currently reserved.",
+				Resolver:    schema.PathResolver("Details.Network.Reserved"),
+			},
+			{
+				Name:        "networks_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of networks allowed.",
+				Resolver:    schema.PathResolver("Details.Network.Limit"),
+			},
+			{
+				Name:        "subnets_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of subnets currently in use.",
+				Resolver:    schema.PathResolver("Details.Subnet.Used"),
+			},
+			{
+				Name:        "subnets_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of subnets currently reserved.",
+				Resolver:    schema.PathResolver("Details.Subnet.Reserved"),
+			},
+			{
+				Name:        "subnets_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of subnets allowed.",
+				Resolver:    schema.PathResolver("Details.Subnet.Limit"),
+			},
+			{
+				Name:        "ports_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of ports currently in use.",
+				Resolver:    schema.PathResolver("Details.Port.Used"),
+			},
+			{
+				Name:        "ports_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of ports currently reserved.",
+				Resolver:    schema.PathResolver("Details.Port.Reserved"),
+			},
+			{
+				Name:        "ports_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of ports allowed.",
+				Resolver:    schema.PathResolver("Details.Port.Limit"),
+			},
+			{
+				Name:        "routers_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of routers currently in use.",
+				Resolver:    schema.PathResolver("Details.Router.Used"),
+			},
+			{
+				Name:        "routers_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of routers currently reserved.",
+				Resolver:    schema.PathResolver("Details.Router.Reserved"),
+			},
+			{
+				Name:        "routers_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of routers allowed.",
+				Resolver:    schema.PathResolver("Details.Router.Limit"),
+			},
+			{
+				Name:        "floating_ips_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of floating IPs currently in use.",
+				Resolver:    schema.PathResolver("Details.FloatingIP.Used"),
+			},
+			{
+				Name:        "floating_ips_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of floating IPs currently reserved.",
+				Resolver:    schema.PathResolver("Details.FloatingIP.Reserved"),
+			},
+			{
+				Name:        "floating_ips_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of floating IPs allowed.",
+				Resolver:    schema.PathResolver("Details.FloatingIP.Limit"),
+			},
+			{
+				Name:        "security_groups_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of security groups currently in use.",
+				Resolver:    schema.PathResolver("Details.SecurityGroup.Used"),
+			},
+			{
+				Name:        "security_groups_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of security groups currently reserved.",
+				Resolver:    schema.PathResolver("Details.SecurityGroup.Reserved"),
+			},
+			{
+				Name:        "security_groups_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of security groups allowed.",
+				Resolver:    schema.PathResolver("Details.SecurityGroup.Limit"),
+			},
+			{
+				Name:        "security_group_rules_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of security group rules currently in use.",
+				Resolver:    schema.PathResolver("Details.SecurityGroupRule.Used"),
+			},
+			{
+				Name:        "security_group_rules_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of security group rules currently reserved.",
+				Resolver:    schema.PathResolver("Details.SecurityGroupRule.Reserved"),
+			},
+			{
+				Name:        "security_group_rules_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of security group rules allowed.",
+				Resolver:    schema.PathResolver("Details.SecurityGroupRule.Limit"),
+			},
+			{
+				Name:        "rbac_policies_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of RBAC policies currently in use.",
+				Resolver:    schema.PathResolver("Details.RBACPolicy.Used"),
+			},
+			{
+				Name:        "rbac_policies_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of RBAC policies currently reserved.",
+				Resolver:    schema.PathResolver("Details.RBACPolicy.Reserved"),
+			},
+			{
+				Name:        "rbac_policies_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of RBAC policies allowed.",
+				Resolver:    schema.PathResolver("Details.RBACPolicy.Limit"),
+			},
+			{
+				Name:        "subnet_pools_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of subnet pools currently in use.",
+				Resolver:    schema.PathResolver("Details.SubnetPool.Used"),
+			},
+			{
+				Name:        "subnet_pools_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of subnet pools currently reserved.",
+				Resolver:    schema.PathResolver("Details.SubnetPool.Reserved"),
+			},
+			{
+				Name:        "subnet_pools_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of subnet pools allowed.",
+				Resolver:    schema.PathResolver("Details.SubnetPool.Limit"),
+			},
+			{
+				Name:        "trunks_used",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of trunks currently in use.",
+				Resolver:    schema.PathResolver("Details.Trunk.Used"),
+			},
+			{
+				Name:        "trunks_reserved",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The number of trunks currently reserved.",
+				Resolver:    schema.PathResolver("Details.Trunk.Reserved"),
+			},
+			{
+				Name:        "trunks_limit",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum number of trunks allowed.",
+				Resolver:    schema.PathResolver("Details.Trunk.Limit"),
+			},
+		},
+	}
+}
+
+func fetchQuotas(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	// get list of all projects
+	identity, err := api.GetServiceClient(client.IdentityV3)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving identity client")
+		return err
+	}
+
+	allPages, err := projects.List(identity, &projects.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing projects")
+		return err
+	}
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting projects")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allProjects)).Msg("projects retrieved")
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving networking client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, QuotaDetailsExtension); err != nil || !ok {
+		return err
+	}
+
+	// for each project, get the associated quota details
+	for _, project := range allProjects {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		details, err := quotas.GetDetail(networking, project.ID).Extract()
+		if err != nil {
+			api.Logger().Error().Err(err).Str("project id", project.ID).Msg("error extracting quota details for project")
+			return err
+		}
+		api.Logger().Debug().Str("project id", project.ID).Msg("streaming networking quotas")
+		res <- &Quota{
+			ProjectID: project.ID,
+			Details:   details,
+		}
+	}
+	return nil
+}
+
+// Quota associates the Neutron quota details (limit, used and reserved) of
+// each resource type with the project they refer to.
+type Quota struct {
+	ProjectID string                 `json:"project_id" cq-name:"project_id"`
+	Details   *quotas.QuotaDetailSet `json:"-"`
+}
@@ -0,0 +1,61 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/rbacpolicies"
+)
+
+func RBACPolicies(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_rbac_policies_" + installation,
+		Resolver: fetchRBACPolicies,
+		Transform: transformers.TransformWithStruct(
+			&rbacpolicies.RBACPolicy{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchRBACPolicies(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := rbacpolicies.ListOpts{}
+
+	allPages, err := rbacpolicies.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing rbac policies with options")
+		return err
+	}
+	allPolicies, err := rbacpolicies.ExtractRBACPolicies(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting rbac policies")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allPolicies)).Msg("rbac policies retrieved")
+
+	for _, policy := range allPolicies {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		policy := policy
+		api.Logger().Debug().Str("id", policy.ID).Str("object type", policy.ObjectType).Str("object id", policy.ObjectID).Msg("streaming rbac policy")
+		res <- policy
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/dihedron/cq-source-openstack/resources/services/compute"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+// SecurityGroupExposures is a view-like table that links each security group
+// rule to the instance ports it is enforced on, with remote groups resolved to
+// the addresses of their member ports, so that exposure reviews can be run
+// without joining ports, instances and rules by hand.
+func SecurityGroupExposures(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_security_group_exposures_" + installation,
+		Resolver: fetchSecurityGroupExposures,
+		Transform: transformers.TransformWithStruct(
+			&SecurityGroupExposure{},
+			transformers.WithPrimaryKeys("RuleID", "PortID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchSecurityGroupExposures(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving networking client")
+		return err
+	}
+
+	allPages, err := groups.List(networking, groups.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing security groups")
+		return err
+	}
+	allSecurityGroups, err := groups.ExtractGroups(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting security groups")
+		return err
+	}
+	groupNames := map[string]string{}
+	for _, group := range allSecurityGroups {
+		groupNames[group.ID] = group.Name
+	}
+
+	allPages, err = rules.List(networking, rules.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing security group rules")
+		return err
+	}
+	allSecurityGroupRules, err := ExtractSecurityGroupRules(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting security group rules")
+		return err
+	}
+
+	allPages, err = ports.List(networking, ports.ListOpts{}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing ports")
+		return err
+	}
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting ports")
+		return err
+	}
+
+	// the ports are authoritative on which groups apply to which instance, since
+	// they reference security groups by ID, whereas the instances only list the
+	// (non unique) group names, as in compute.InstanceSecurityGroups; instances
+	// are only used to resolve the name of the device bound to the port
+	computeClient, err := api.GetServiceClient(client.ComputeV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving compute client")
+		return err
+	}
+	allPages, err = servers.List(computeClient, servers.ListOpts{AllTenants: true}).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error listing instances")
+		return err
+	}
+	allInstances := []*compute.Instance{}
+	if err = servers.ExtractServersInto(allPages, &allInstances); err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting instances")
+		return err
+	}
+	instanceNames := map[string]string{}
+	for _, instance := range allInstances {
+		instanceNames[instance.ID] = instance.Name
+	}
+
+	// index ports and their addresses by security group
+	groupPorts := map[string][]ports.Port{}
+	groupAddresses := map[string][]string{}
+	for _, port := range allPorts {
+		for _, groupID := range port.SecurityGroups {
+			groupPorts[groupID] = append(groupPorts[groupID], port)
+			for _, ip := range port.FixedIPs {
+				groupAddresses[groupID] = append(groupAddresses[groupID], ip.IPAddress)
+			}
+		}
+	}
+	api.Logger().Debug().Int("rules", len(allSecurityGroupRules)).Int("ports", len(allPorts)).Int("instances", len(allInstances)).Msg("security group exposure data retrieved")
+
+	for _, rule := range allSecurityGroupRules {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		from, to := NormalisePortRange(rule)
+		icmpType, icmpCode := ICMPTypeCode(rule)
+		for _, port := range groupPorts[rule.SecGroupID] {
+			exposure := &SecurityGroupExposure{
+				RuleID:            rule.ID,
+				SecurityGroupID:   rule.SecGroupID,
+				SecurityGroupName: groupNames[rule.SecGroupID],
+				ProjectID:         rule.ProjectID,
+				Direction:         rule.Direction,
+				EtherType:         rule.EtherType,
+				Protocol:          rule.Protocol,
+				PortRangeFrom:     from,
+				PortRangeTo:       to,
+				PortRange:         FormatPortRange(rule),
+				ICMPType:          icmpType,
+				ICMPCode:          icmpCode,
+				RemoteIPPrefix:    rule.RemoteIPPrefix,
+				RemoteGroupID:     rule.RemoteGroupID,
+				WorldOpen:         IsWorldOpen(rule),
+				SensitivePorts:    MatchedSensitivePorts(rule),
+				PortID:            port.ID,
+				NetworkID:         port.NetworkID,
+				DeviceOwner:       port.DeviceOwner,
+			}
+			if rule.RemoteGroupID != "" {
+				exposure.RemoteIPAddresses = groupAddresses[rule.RemoteGroupID]
+			}
+			if name, ok := instanceNames[port.DeviceID]; ok {
+				exposure.InstanceID = port.DeviceID
+				exposure.InstanceName = name
+			}
+			for _, ip := range port.FixedIPs {
+				exposure.IPAddresses = append(exposure.IPAddresses, ip.IPAddress)
+			}
+			exposure.Exposed = exposure.WorldOpen && len(exposure.SensitivePorts) > 0
+			api.Logger().Debug().Str("rule id", rule.ID).Str("port id", port.ID).Msg("streaming security group exposure")
+			res <- exposure
+		}
+	}
+	return nil
+}
+
+type SecurityGroupExposure struct {
+	RuleID            string   `json:"rule_id"`
+	SecurityGroupID   string   `json:"security_group_id"`
+	SecurityGroupName string   `json:"security_group_name"`
+	ProjectID         string   `json:"project_id"`
+	Direction         string   `json:"direction"`
+	EtherType         string   `json:"ethertype" cq-name:"ethertype"`
+	Protocol          string   `json:"protocol"`
+	PortRangeFrom     *int     `json:"port_range_from"`
+	PortRangeTo       *int     `json:"port_range_to"`
+	PortRange         string   `json:"port_range"`
+	ICMPType          *int     `json:"icmp_type"`
+	ICMPCode          *int     `json:"icmp_code"`
+	RemoteIPPrefix    string   `json:"remote_ip_prefix"`
+	RemoteGroupID     string   `json:"remote_group_id"`
+	RemoteIPAddresses []string `json:"remote_ip_addresses"`
+	WorldOpen         bool     `json:"world_open"`
+	SensitivePorts    []int    `json:"sensitive_ports"`
+	Exposed           bool     `json:"exposed"`
+	PortID            string   `json:"port_id"`
+	NetworkID         string   `json:"network_id"`
+	DeviceOwner       string   `json:"device_owner"`
+	InstanceID        string   `json:"instance_id"`
+	InstanceName      string   `json:"instance_name"`
+	IPAddresses       []string `json:"ip_addresses"`
+}
@@ -3,12 +3,14 @@ package networking
 import (
 	"context"
 
+	"github.com/apache/arrow/go/v15/arrow"
 	"github.com/cloudquery/plugin-sdk/v4/schema"
 	"github.com/cloudquery/plugin-sdk/v4/transformers"
 	"github.com/dihedron/cq-plugin-utils/format"
 	"github.com/dihedron/cq-plugin-utils/transform"
 	"github.com/dihedron/cq-source-openstack/client"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/pagination"
 )
 
 func SecurityGroupRules(installation string) *schema.Table {
@@ -16,23 +18,83 @@ func SecurityGroupRules(installation string) *schema.Table {
 		Name:     "openstack_networking_security_group_rules_" + installation,
 		Resolver: fetchSecurityGroupRules,
 		Transform: transformers.TransformWithStruct(
-			&rules.SecGroupRule{},
+			&SecurityGroupRule{},
 			transformers.WithPrimaryKeys("ID"),
 			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
 			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
 			transformers.WithSkipFields("Links"),
 		),
-		// Columns: []schema.Column{
-		// 	{
-		// 		Name:        "security_group_rule_ids",
-		// 		Type:        schema.TypeStringArray,
-		// 		Description: "The collection of IP addresses associated with the port.",
-		// 		Resolver: transform.Apply(
-		// 			transform.OnObjectField("Rules.ID"),
-		// 			transform.NilIfZero(),
-		// 		),
-		// 	},
-		// },
+		Columns: []schema.Column{
+			{
+				Name:        "port_range_from",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The first port matched by the rule, with unset ranges normalised to 1; null for port-less protocols.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					from, _ := NormalisePortRange(r.Item.(*SecurityGroupRule))
+					return r.Set(c.Name, from)
+				},
+			},
+			{
+				Name:        "port_range_to",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The last port matched by the rule, with unset ranges normalised to 65535; null for port-less protocols.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					_, to := NormalisePortRange(r.Item.(*SecurityGroupRule))
+					return r.Set(c.Name, to)
+				},
+			},
+			{
+				Name:        "port_range",
+				Type:        arrow.BinaryTypes.String,
+				Description: "The normalised port range matched by the rule (e.g. \"22\" or \"8000-8080\"), \"any\" when all ports are matched, or the ICMP type and code (e.g. \"type 8 code 0\").",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					return r.Set(c.Name, FormatPortRange(r.Item.(*SecurityGroupRule)))
+				},
+			},
+			{
+				Name:        "icmp_type",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The ICMP type matched by the rule; null for non-ICMP rules and for ICMP rules matching any type.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					icmpType, _ := ICMPTypeCode(r.Item.(*SecurityGroupRule))
+					return r.Set(c.Name, icmpType)
+				},
+			},
+			{
+				Name:        "icmp_code",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The ICMP code matched by the rule; null for non-ICMP rules and for ICMP rules matching any code.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					_, icmpCode := ICMPTypeCode(r.Item.(*SecurityGroupRule))
+					return r.Set(c.Name, icmpCode)
+				},
+			},
+			{
+				Name:        "world_open",
+				Type:        arrow.FixedWidthTypes.Boolean,
+				Description: "Whether the rule allows ingress traffic from any address (0.0.0.0/0 or ::/0).",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					return r.Set(c.Name, IsWorldOpen(r.Item.(*SecurityGroupRule)))
+				},
+			},
+			{
+				Name:        "sensitive_ports",
+				Type:        arrow.ListOf(arrow.PrimitiveTypes.Int64),
+				Description: "The well-known sensitive ports (SSH, RDP, databases...) matched by the rule.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					return r.Set(c.Name, MatchedSensitivePorts(r.Item.(*SecurityGroupRule)))
+				},
+			},
+			{
+				Name:        "exposed",
+				Type:        arrow.FixedWidthTypes.Boolean,
+				Description: "Whether the rule opens at least one sensitive port to the world.",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, r *schema.Resource, c schema.Column) error {
+					rule := r.Item.(*SecurityGroupRule)
+					return r.Set(c.Name, IsWorldOpen(rule) && len(MatchedSensitivePorts(rule)) > 0)
+				},
+			},
+		},
 	}
 }
 
@@ -53,7 +115,7 @@ func fetchSecurityGroupRules(ctx context.Context, meta schema.ClientMeta, parent
 		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing security group rules with options")
 		return err
 	}
-	allSecurityGroupRules, err := rules.ExtractRules(allPages)
+	allSecurityGroupRules, err := ExtractSecurityGroupRules(allPages)
 	if err != nil {
 		api.Logger().Error().Err(err).Msg("error extracting security group rules")
 		return err
@@ -72,3 +134,30 @@ func fetchSecurityGroupRules(ctx context.Context, meta schema.ClientMeta, parent
 	}
 	return nil
 }
+
+// SecurityGroupRule is a security group rule; unlike rules.SecGroupRule, it
+// keeps unset port ranges (or ICMP type and code) as nil rather than zero, so
+// that e.g. an ICMP rule for type 0 code 0 is not mistaken for one matching
+// any type and code.
+type SecurityGroupRule struct {
+	ID             string
+	Direction      string
+	Description    string `json:"description"`
+	EtherType      string `json:"ethertype"`
+	SecGroupID     string `json:"security_group_id"`
+	PortRangeMin   *int   `json:"port_range_min"`
+	PortRangeMax   *int   `json:"port_range_max"`
+	Protocol       string
+	RemoteGroupID  string `json:"remote_group_id"`
+	RemoteIPPrefix string `json:"remote_ip_prefix"`
+	TenantID       string `json:"tenant_id"`
+	ProjectID      string `json:"project_id"`
+}
+
+func ExtractSecurityGroupRules(r pagination.Page) ([]*SecurityGroupRule, error) {
+	var s struct {
+		SecurityGroupRules []*SecurityGroupRule `json:"security_group_rules"`
+	}
+	err := (r.(rules.SecGroupRulePage)).ExtractInto(&s)
+	return s.SecurityGroupRules, err
+}
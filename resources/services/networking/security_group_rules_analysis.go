@@ -0,0 +1,150 @@
+package networking
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MinPort is the first valid TCP/UDP port.
+	MinPort = 1
+	// MaxPort is the last valid TCP/UDP port.
+	MaxPort = 65535
+)
+
+// SensitivePorts are the well-known ports that should never be reachable
+// from the whole Internet, mapped to the service usually listening on them.
+var SensitivePorts = map[int]string{
+	22:    "ssh",
+	23:    "telnet",
+	135:   "msrpc",
+	139:   "netbios",
+	445:   "smb",
+	1433:  "mssql",
+	1521:  "oracle",
+	2049:  "nfs",
+	2375:  "docker",
+	2376:  "docker-tls",
+	3306:  "mysql",
+	3389:  "rdp",
+	5432:  "postgresql",
+	5900:  "vnc",
+	5984:  "couchdb",
+	6379:  "redis",
+	9200:  "elasticsearch",
+	11211: "memcached",
+	27017: "mongodb",
+}
+
+// portBasedProtocols are the protocols for which the port range of a rule
+// is a range of ports (as opposed to e.g. ICMP, where it holds type and code);
+// they are listed both by name and by IANA number.
+var portBasedProtocols = map[string]bool{
+	"tcp":     true,
+	"6":       true,
+	"udp":     true,
+	"17":      true,
+	"dccp":    true,
+	"33":      true,
+	"sctp":    true,
+	"132":     true,
+	"udplite": true,
+	"136":     true,
+}
+
+// icmpProtocols are the protocols for which the port range of a rule holds
+// the ICMP type and code, listed both by name and by IANA number.
+var icmpProtocols = map[string]bool{
+	"icmp":      true,
+	"1":         true,
+	"ipv6-icmp": true,
+	"icmpv6":    true,
+	"58":        true,
+}
+
+// isAnyProtocol returns whether the rule matches all protocols.
+func isAnyProtocol(rule *SecurityGroupRule) bool {
+	protocol := strings.ToLower(rule.Protocol)
+	return protocol == "" || protocol == "any" || protocol == "0"
+}
+
+// NormalisePortRange returns the first and last port matched by the rule;
+// unset bounds are normalised to the whole port range, whereas nil values
+// are returned for protocols that have no notion of ports.
+func NormalisePortRange(rule *SecurityGroupRule) (*int, *int) {
+	if !isAnyProtocol(rule) && !portBasedProtocols[strings.ToLower(rule.Protocol)] {
+		return nil, nil
+	}
+	from, to := MinPort, MaxPort
+	if rule.PortRangeMin != nil && *rule.PortRangeMin != 0 {
+		from = *rule.PortRangeMin
+	}
+	if rule.PortRangeMax != nil && *rule.PortRangeMax != 0 {
+		to = *rule.PortRangeMax
+	}
+	return &from, &to
+}
+
+// ICMPTypeCode returns the ICMP type and code matched by the rule; nil values
+// are returned for non-ICMP rules and when the rule matches any type or code.
+func ICMPTypeCode(rule *SecurityGroupRule) (*int, *int) {
+	if !icmpProtocols[strings.ToLower(rule.Protocol)] {
+		return nil, nil
+	}
+	return rule.PortRangeMin, rule.PortRangeMax
+}
+
+// FormatPortRange returns a human readable, normalised representation of
+// the ports (or ICMP type and code) matched by the rule.
+func FormatPortRange(rule *SecurityGroupRule) string {
+	from, to := NormalisePortRange(rule)
+	switch {
+	case from == nil:
+		icmpType, icmpCode := ICMPTypeCode(rule)
+		switch {
+		case icmpType == nil:
+			return "any"
+		case icmpCode == nil:
+			return fmt.Sprintf("type %d", *icmpType)
+		default:
+			return fmt.Sprintf("type %d code %d", *icmpType, *icmpCode)
+		}
+	case *from == MinPort && *to == MaxPort:
+		return "any"
+	case *from == *to:
+		return fmt.Sprintf("%d", *from)
+	default:
+		return fmt.Sprintf("%d-%d", *from, *to)
+	}
+}
+
+// IsWorldOpen returns whether the rule lets in traffic from any address:
+// Neutron treats a missing remote prefix and remote group as "anywhere".
+func IsWorldOpen(rule *SecurityGroupRule) bool {
+	if rule.Direction != "ingress" || rule.RemoteGroupID != "" {
+		return false
+	}
+	switch rule.RemoteIPPrefix {
+	case "", "0.0.0.0/0", "::/0":
+		return true
+	}
+	return false
+}
+
+// MatchedSensitivePorts returns the sensitive ports that fall within the
+// port range of the rule, in ascending order.
+func MatchedSensitivePorts(rule *SecurityGroupRule) []int {
+	from, to := NormalisePortRange(rule)
+	if from == nil {
+		return nil
+	}
+	matched := []int{}
+	for port := range SensitivePorts {
+		if port >= *from && port <= *to {
+			matched = append(matched, port)
+		}
+	}
+	sort.Ints(matched)
+	return matched
+}
@@ -0,0 +1,138 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-plugin-utils/utils"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+func Segments(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_segments_" + installation,
+		Resolver: fetchSegments,
+		Transform: transformers.TransformWithStruct(
+			&Segment{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchSegments(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	allPages, err := ListSegments(networking).AllPages()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Err(err).Msg("segment extension not available")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("err type", fmt.Sprintf("%T", err)).Msg("error listing segments")
+		return err
+	}
+	allSegments, err := ExtractSegments(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting segments")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allSegments)).Msg("segments retrieved")
+
+	for _, segment := range allSegments {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		segment := segment
+		api.Logger().Debug().Str("id", segment.ID).Str("network id", segment.NetworkID).Msg("streaming segment")
+		res <- segment
+	}
+	return nil
+}
+
+// Segment is a network segment as returned by the Neutron segment extension,
+// which is not supported by gophercloud.
+type Segment struct {
+	ID              string      `json:"id"`
+	NetworkID       string      `json:"network_id"`
+	Name            string      `json:"name"`
+	Description     string      `json:"description"`
+	NetworkType     string      `json:"network_type"`
+	PhysicalNetwork string      `json:"physical_network"`
+	SegmentationID  *int        `json:"segmentation_id"`
+	RevisionNumber  int         `json:"revision_number"`
+	CreatedAt       *utils.Time `json:"created_at" cq-type:"timestamp"`
+	UpdatedAt       *utils.Time `json:"updated_at" cq-type:"timestamp"`
+}
+
+func (r *Segment) UnmarshalJSON(b []byte) error {
+	type tmp Segment
+	var s struct {
+		tmp
+		SegmentationID interface{} `json:"segmentation_id"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*r = Segment(s.tmp)
+
+	if r.SegmentationID, err = parseSegmentationID(s.SegmentationID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type SegmentPage struct {
+	pagination.LinkedPageBase
+}
+
+func (r SegmentPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"segments_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+func (r SegmentPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+	segments, err := ExtractSegments(r)
+	return len(segments) == 0, err
+}
+
+func ListSegments(c *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(c, c.ServiceURL("segments"), func(r pagination.PageResult) pagination.Page {
+		return SegmentPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func ExtractSegments(r pagination.Page) ([]*Segment, error) {
+	var s struct {
+		Segments []*Segment `json:"segments"`
+	}
+	err := (r.(SegmentPage)).ExtractInto(&s)
+	return s.Segments, err
+}
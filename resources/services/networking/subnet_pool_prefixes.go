@@ -0,0 +1,36 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/dihedron/cq-plugin-utils/utils"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/subnetpools"
+)
+
+func SubnetPoolPrefixes(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_subnet_pool_prefixes_" + installation,
+		Resolver: fetchSubnetPoolPrefixes,
+		Transform: transformers.TransformWithStruct(
+			&utils.Single[string]{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchSubnetPoolPrefixes(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+	api := meta.(*client.Client)
+	pool := parent.Item.(subnetpools.SubnetPool)
+	for _, v := range pool.Prefixes {
+		prefix := &utils.Single[string]{Name: v}
+		api.Logger().Debug().Str("subnet pool id", pool.ID).Str("prefix", v).Msg("streaming subnet pool prefix")
+		res <- prefix
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/subnetpools"
+)
+
+func SubnetPools(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_subnet_pools_" + installation,
+		Resolver: fetchSubnetPools,
+		Transform: transformers.TransformWithStruct(
+			&subnetpools.SubnetPool{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+		Relations: []*schema.Table{
+			SubnetPoolPrefixes(installation),
+		},
+		Columns: []schema.Column{
+			{
+				Name:        "default_prefixlen",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The size of the prefix to allocate when none is specified at subnet creation.",
+				Resolver:    schema.PathResolver("DefaultPrefixLen"),
+			},
+			{
+				Name:        "min_prefixlen",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The smallest prefix that can be allocated from the subnet pool.",
+				Resolver:    schema.PathResolver("MinPrefixLen"),
+			},
+			{
+				Name:        "max_prefixlen",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The maximum prefix size that can be allocated from the subnet pool.",
+				Resolver:    schema.PathResolver("MaxPrefixLen"),
+			},
+			{
+				Name:        "created_at",
+				Type:        arrow.FixedWidthTypes.Timestamp_us,
+				Description: "The time at which the subnet pool was created.",
+				Resolver: transform.Apply(
+					transform.OnObjectField("CreatedAt"),
+					transform.NilIfZero(),
+				),
+			},
+			{
+				Name:        "updated_at",
+				Type:        arrow.FixedWidthTypes.Timestamp_us,
+				Description: "The time at which the subnet pool was last updated.",
+				Resolver: transform.Apply(
+					transform.OnObjectField("UpdatedAt"),
+					transform.NilIfZero(),
+				),
+			},
+		},
+	}
+}
+
+func fetchSubnetPools(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := subnetpools.ListOpts{}
+
+	allPages, err := subnetpools.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing subnet pools with options")
+		return err
+	}
+	allSubnetPools, err := subnetpools.ExtractSubnetPools(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting subnet pools")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allSubnetPools)).Msg("subnet pools retrieved")
+
+	for _, pool := range allSubnetPools {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		pool := pool
+		api.Logger().Debug().Str("id", pool.ID).Msg("streaming subnet pool")
+		res <- pool
+	}
+	return nil
+}
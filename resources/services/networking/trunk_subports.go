@@ -0,0 +1,33 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+)
+
+func TrunkSubports(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_trunk_subports_" + installation,
+		Resolver: fetchTrunkSubports,
+		Transform: transformers.TransformWithStruct(
+			&trunks.Subport{},
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchTrunkSubports(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+	api := meta.(*client.Client)
+	trunk := parent.Item.(trunks.Trunk)
+	for _, subport := range trunk.Subports {
+		api.Logger().Debug().Str("trunk id", trunk.ID).Str("port id", subport.PortID).Msg("streaming trunk subport")
+		res <- subport
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+)
+
+func Trunks(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_trunks_" + installation,
+		Resolver: fetchTrunks,
+		Transform: transformers.TransformWithStruct(
+			&trunks.Trunk{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("Subports"),
+		),
+		Relations: []*schema.Table{
+			TrunkSubports(installation),
+		},
+	}
+}
+
+func fetchTrunks(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	opts := trunks.ListOpts{}
+
+	allPages, err := trunks.List(networking, opts).AllPages()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			api.Logger().Warn().Err(err).Msg("trunk extension not available")
+			return nil
+		}
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Str("err type", fmt.Sprintf("%T", err)).Msg("error listing trunks with options")
+		return err
+	}
+	allTrunks, err := trunks.ExtractTrunks(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting trunks")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allTrunks)).Msg("trunks retrieved")
+
+	for _, trunk := range allTrunks {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		trunk := trunk
+		api.Logger().Debug().Str("id", trunk.ID).Msg("streaming trunk")
+		res <- trunk
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+)
+
+func VPNEndpointGroups(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_vpn_endpoint_groups_" + installation,
+		Resolver: fetchVPNEndpointGroups,
+		Transform: transformers.TransformWithStruct(
+			&endpointgroups.EndpointGroup{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVPNEndpointGroups(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, VPNEndpointGroupsExtension); err != nil || !ok {
+		return err
+	}
+
+	opts := endpointgroups.ListOpts{}
+
+	allPages, err := endpointgroups.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing vpn endpoint groups with options")
+		return err
+	}
+	allEndpointGroups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting vpn endpoint groups")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allEndpointGroups)).Msg("vpn endpoint groups retrieved")
+
+	for _, group := range allEndpointGroups {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		group := group
+		api.Logger().Debug().Str("id", group.ID).Msg("streaming vpn endpoint group")
+		res <- group
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+)
+
+func VPNIKEPolicies(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_vpn_ike_policies_" + installation,
+		Resolver: fetchVPNIKEPolicies,
+		Transform: transformers.TransformWithStruct(
+			&ikepolicies.Policy{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("Lifetime"),
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "lifetime_units",
+				Type:        arrow.BinaryTypes.String,
+				Description: "The units of the security association lifetime.",
+				Resolver:    schema.PathResolver("Lifetime.Units"),
+			},
+			{
+				Name:        "lifetime_value",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The value of the security association lifetime.",
+				Resolver:    schema.PathResolver("Lifetime.Value"),
+			},
+		},
+	}
+}
+
+func fetchVPNIKEPolicies(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, VPNaaSExtension); err != nil || !ok {
+		return err
+	}
+
+	opts := ikepolicies.ListOpts{}
+
+	allPages, err := ikepolicies.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing ike policies with options")
+		return err
+	}
+	allPolicies, err := ikepolicies.ExtractPolicies(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting ike policies")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allPolicies)).Msg("ike policies retrieved")
+
+	for _, policy := range allPolicies {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		policy := policy
+		api.Logger().Debug().Str("id", policy.ID).Msg("streaming ike policy")
+		res <- policy
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+func VPNIPSecSiteConnections(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_vpn_ipsec_site_connections_" + installation,
+		Resolver: fetchVPNIPSecSiteConnections,
+		Transform: transformers.TransformWithStruct(
+			&siteconnections.Connection{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+			transformers.WithSkipFields("PSK", "DPD"),                      // never store the pre-shared key
+		),
+		Columns: []schema.Column{
+			{
+				Name:        "dpd_action",
+				Type:        arrow.BinaryTypes.String,
+				Description: "The dead peer detection action.",
+				Resolver:    schema.PathResolver("DPD.Action"),
+			},
+			{
+				Name:        "dpd_interval",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The dead peer detection interval, in seconds.",
+				Resolver:    schema.PathResolver("DPD.Interval"),
+			},
+			{
+				Name:        "dpd_timeout",
+				Type:        arrow.PrimitiveTypes.Int64,
+				Description: "The dead peer detection timeout, in seconds.",
+				Resolver:    schema.PathResolver("DPD.Timeout"),
+			},
+		},
+	}
+}
+
+func fetchVPNIPSecSiteConnections(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, VPNaaSExtension); err != nil || !ok {
+		return err
+	}
+
+	opts := siteconnections.ListOpts{}
+
+	allPages, err := siteconnections.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing ipsec site connections with options")
+		return err
+	}
+	allConnections, err := siteconnections.ExtractConnections(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting ipsec site connections")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allConnections)).Msg("ipsec site connections retrieved")
+
+	for _, connection := range allConnections {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		connection := connection
+		api.Logger().Debug().Str("id", connection.ID).Msg("streaming ipsec site connection")
+		res <- connection
+	}
+	return nil
+}
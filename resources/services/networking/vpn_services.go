@@ -0,0 +1,65 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/cloudquery/plugin-sdk/v4/schema"
+	"github.com/cloudquery/plugin-sdk/v4/transformers"
+	"github.com/dihedron/cq-plugin-utils/format"
+	"github.com/dihedron/cq-plugin-utils/transform"
+	"github.com/dihedron/cq-source-openstack/client"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/services"
+)
+
+func VPNServices(installation string) *schema.Table {
+	return &schema.Table{
+		Name:     "openstack_networking_vpn_services_" + installation,
+		Resolver: fetchVPNServices,
+		Transform: transformers.TransformWithStruct(
+			&services.Service{},
+			transformers.WithPrimaryKeys("ID"),
+			transformers.WithNameTransformer(transform.TagNameTransformer), // use cq-name tags to translate name
+			transformers.WithTypeTransformer(transform.TagTypeTransformer), // use cq-type tags to translate type
+		),
+	}
+}
+
+func fetchVPNServices(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+
+	api := meta.(*client.Client)
+
+	networking, err := api.GetServiceClient(client.NetworkingV2)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error retrieving client")
+		return err
+	}
+
+	if ok, err := hasExtension(api, networking, VPNaaSExtension); err != nil || !ok {
+		return err
+	}
+
+	opts := services.ListOpts{}
+
+	allPages, err := services.List(networking, opts).AllPages()
+	if err != nil {
+		api.Logger().Error().Err(err).Str("options", format.ToPrettyJSON(opts)).Msg("error listing vpn services with options")
+		return err
+	}
+	allServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		api.Logger().Error().Err(err).Msg("error extracting vpn services")
+		return err
+	}
+	api.Logger().Debug().Int("count", len(allServices)).Msg("vpn services retrieved")
+
+	for _, service := range allServices {
+		if ctx.Err() != nil {
+			api.Logger().Debug().Msg("context done, exit")
+			break
+		}
+		service := service
+		api.Logger().Debug().Str("id", service.ID).Msg("streaming vpn service")
+		res <- service
+	}
+	return nil
+}